@@ -0,0 +1,268 @@
+// Package attribution renders credits for Pexels photos and videos, following the
+// Pexels guidelines of naming the photographer and linking to them and to Pexels.
+//
+//	renderer := attribution.New(attribution.Options{Locale: attribution.LocaleFor(types.LocaleDeDE)})
+//	credit := attribution.FromMedia(&photo)
+//	renderer.Text(credit)     // Foto von Min An auf Pexels
+//	renderer.Markdown(credit) // Foto von [Min An](https://www.pexels.com/@min-an) auf [Pexels](https://www.pexels.com/photo/...)
+//	renderer.HTML(credit)     // template.HTML with escaped text and links
+//
+// Credits for many media are aggregated per creator with Page.
+package attribution
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// PexelsURL is linked from credits of media without a page URL.
+const PexelsURL = "https://www.pexels.com"
+
+// LicenseURL is the page of the Pexels license.
+const LicenseURL = "https://www.pexels.com/license/"
+
+// DefaultRel is the rel attribute of the links of HTML credits.
+const DefaultRel = "noopener noreferrer"
+
+// Format selects how credits are rendered.
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatJSONLD   Format = "jsonld"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{FormatText, FormatMarkdown, FormatHTML, FormatJSONLD}
+
+// Credit holds what is needed to credit one photo or video.
+type Credit struct {
+	MediaType string     // types.MediaTypePhoto or types.MediaTypeVideo
+	MediaID   int        // Photo or video ID
+	MediaURL  string     // URL of the Pexels page of the media
+	ImageURL  string     // URL of the photo, or of a still image of the video
+	Alt       string     // Description of the media, if any
+	Creator   types.User // Photographer or videographer
+}
+
+// FromMedia returns the credit of a photo or video. Nil media, including nil
+// *types.Photo and *types.Video values, have the zero Credit.
+func FromMedia(media types.Media) Credit {
+	if isNil(media) {
+		return Credit{}
+	}
+	credit := Credit{
+		MediaType: media.MediaType(),
+		MediaID:   media.MediaID(),
+		MediaURL:  media.MediaURL(),
+		Creator:   media.Creator(),
+	}
+	switch m := media.(type) {
+	case *types.Photo:
+		credit.ImageURL, credit.Alt = m.Src.Original, m.Alt
+	case *types.Video:
+		credit.ImageURL = m.Image
+	}
+	return credit
+}
+
+// isNil reports whether media is nil or a nil *types.Photo or *types.Video,
+// as found in a types.MediaList decoded from null elements.
+func isNil(media types.Media) bool {
+	switch m := media.(type) {
+	case nil:
+		return true
+	case *types.Photo:
+		return m == nil
+	case *types.Video:
+		return m == nil
+	}
+	return false
+}
+
+// FromMediaItem returns the credit of a flattened collection media item.
+func FromMediaItem(item types.MediaItem) (Credit, error) {
+	media, err := item.Media()
+	if err != nil {
+		return Credit{}, fmt.Errorf("error crediting media %d: %w", item.ID, err)
+	}
+	return FromMedia(media), nil
+}
+
+// Options configures a Renderer. Zero values select the defaults.
+type Options struct {
+	Locale Locale // Messages; defaults to English
+	Rel    string // rel attribute of HTML links; defaults to DefaultRel
+	Target string // Optional target attribute of HTML links, e.g. "_blank"
+}
+
+// Renderer renders credits in the supported formats. It is safe for concurrent use.
+type Renderer struct {
+	locale Locale
+	rel    string
+	target string
+}
+
+// New returns a Renderer configured with opts.
+func New(opts Options) *Renderer {
+	if opts.Rel == "" {
+		opts.Rel = DefaultRel
+	}
+	return &Renderer{locale: opts.Locale.withDefaults(), rel: opts.Rel, target: opts.Target}
+}
+
+// Render renders the credit line of c in the given format.
+func (r *Renderer) Render(format Format, c Credit) (string, error) {
+	switch format {
+	case FormatText:
+		return r.Text(c), nil
+	case FormatMarkdown:
+		return r.Markdown(c), nil
+	case FormatHTML:
+		return string(r.HTML(c)), nil
+	case FormatJSONLD:
+		data, err := r.JSONLD(c)
+		return string(data), err
+	}
+	return "", fmt.Errorf("unknown attribution format %q", format)
+}
+
+// Text returns the credit line of c as plain text.
+func (r *Renderer) Text(c Credit) string {
+	return expand(r.message(c), func(s string) string { return s }, c.Creator.Name, "Pexels")
+}
+
+// Markdown returns the credit line of c as Markdown, linking the creator and the media page.
+func (r *Renderer) Markdown(c Credit) string {
+	return expand(r.message(c), escapeMarkdown,
+		markdownLink(c.Creator.Name, c.Creator.URL),
+		markdownLink("Pexels", pageURL(c)))
+}
+
+// HTML returns the credit line of c as HTML, linking the creator and the media page.
+// Text and attributes are escaped and links other than http and https are dropped.
+func (r *Renderer) HTML(c Credit) template.HTML {
+	return template.HTML(expand(r.message(c), template.HTMLEscapeString,
+		r.htmlLink(c.Creator.Name, c.Creator.URL),
+		r.htmlLink("Pexels", pageURL(c))))
+}
+
+// JSONLD returns the schema.org ImageObject or VideoObject describing c, for use in
+// a <script type="application/ld+json"> element. HTML characters are escaped.
+func (r *Renderer) JSONLD(c Credit) ([]byte, error) {
+	object := r.jsonLD(c)
+	object["@context"] = "https://schema.org"
+	return json.Marshal(object)
+}
+
+// jsonLD returns the schema.org object of c without its context.
+func (r *Renderer) jsonLD(c Credit) map[string]any {
+	object := map[string]any{
+		"@type":              "ImageObject",
+		"creditText":         r.Text(c),
+		"license":            LicenseURL,
+		"acquireLicensePage": pageURL(c),
+		"creator": map[string]any{
+			"@type": "Person",
+			"name":  c.Creator.Name,
+			"url":   c.Creator.URL,
+		},
+		"copyrightNotice": c.Creator.Name,
+	}
+	if c.MediaType == types.MediaTypeVideo {
+		object["@type"] = "VideoObject"
+		if c.ImageURL != "" {
+			object["thumbnailUrl"] = c.ImageURL
+		}
+	} else if c.ImageURL != "" {
+		object["contentUrl"] = c.ImageURL
+	}
+	if c.MediaURL != "" {
+		object["url"] = c.MediaURL
+	}
+	if c.Alt != "" {
+		object["name"] = c.Alt
+	}
+	return object
+}
+
+// message returns the credit line template of c.
+func (r *Renderer) message(c Credit) string {
+	if c.MediaType == types.MediaTypeVideo {
+		return r.locale.Video
+	}
+	return r.locale.Photo
+}
+
+// label returns the link text of c on a credits page, e.g. "Photo 2014422".
+func (r *Renderer) label(c Credit) string {
+	if c.MediaType == types.MediaTypeVideo {
+		return fmt.Sprintf("%s %d", r.locale.VideoLabel, c.MediaID)
+	}
+	return fmt.Sprintf("%s %d", r.locale.PhotoLabel, c.MediaID)
+}
+
+// htmlLink returns an escaped <a> element, or the escaped text alone when href is not a web URL.
+func (r *Renderer) htmlLink(text, href string) string {
+	href = webURL(href)
+	if href == "" {
+		return template.HTMLEscapeString(text)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, `<a href="%s" rel="%s"`, template.HTMLEscapeString(href), template.HTMLEscapeString(r.rel))
+	if r.target != "" {
+		fmt.Fprintf(&b, ` target="%s"`, template.HTMLEscapeString(r.target))
+	}
+	fmt.Fprintf(&b, ">%s</a>", template.HTMLEscapeString(text))
+	return b.String()
+}
+
+// expand escapes message with escape and replaces its placeholders with the rendered creator and Pexels link.
+func expand(message string, escape func(string) string, creator, pexels string) string {
+	return strings.NewReplacer(PlaceholderCreator, creator, PlaceholderPexels, pexels).Replace(escape(message))
+}
+
+// markdownLink returns a Markdown link, or the escaped text alone when href is not a web URL.
+func markdownLink(text, href string) string {
+	href = webURL(href)
+	if href == "" {
+		return escapeMarkdown(text)
+	}
+	href = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(href)
+	return fmt.Sprintf("[%s](%s)", escapeMarkdown(text), href)
+}
+
+// markdownEscaper escapes the characters with a meaning in Markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"(", `\(`, ")", `\)`, "<", `\<`, ">", `\>`, "#", `\#`, "!", `\!`, "|", `\|`,
+)
+
+// escapeMarkdown escapes text for use in Markdown.
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// pageURL returns the page of the media, or the Pexels home page when it is unknown.
+func pageURL(c Credit) string {
+	if webURL(c.MediaURL) == "" {
+		return PexelsURL
+	}
+	return c.MediaURL
+}
+
+// webURL returns rawURL if it is an absolute http or https URL, and "" otherwise.
+func webURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return ""
+	}
+	return rawURL
+}
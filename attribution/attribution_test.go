@@ -0,0 +1,155 @@
+package attribution
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+var (
+	photo = types.Photo{
+		ID:              2014422,
+		URL:             "https://www.pexels.com/photo/2014422/",
+		Photographer:    `Joey <b>"Farina"</b>`,
+		PhotographerID:  680589,
+		PhotographerURL: "https://www.pexels.com/@joey",
+		Alt:             "Brown rock formation",
+		Src:             types.PhotoSrc{Original: "https://images.pexels.com/photos/2014422/pexels-photo-2014422.jpeg"},
+	}
+	video = types.Video{
+		ID:    857251,
+		URL:   "https://www.pexels.com/video/857251/",
+		Image: "https://images.pexels.com/videos/857251/preview.jpeg",
+		User:  types.User{ID: 680589, Name: `Joey <b>"Farina"</b>`, URL: "https://www.pexels.com/@joey"},
+	}
+)
+
+// Test the credit line of a photo in every format
+func TestRender(t *testing.T) {
+	renderer := New(Options{Target: "_blank"})
+	credit := FromMedia(&photo)
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatText, `Photo by Joey <b>"Farina"</b> on Pexels`},
+		{FormatMarkdown, `Photo by [Joey \<b\>"Farina"\</b\>](https://www.pexels.com/@joey) on [Pexels](https://www.pexels.com/photo/2014422/)`},
+		{FormatHTML, `Photo by <a href="https://www.pexels.com/@joey" rel="noopener noreferrer" target="_blank">Joey &lt;b&gt;&#34;Farina&#34;&lt;/b&gt;</a> on ` +
+			`<a href="https://www.pexels.com/photo/2014422/" rel="noopener noreferrer" target="_blank">Pexels</a>`},
+	}
+	for _, test := range tests {
+		got, err := renderer.Render(test.format, credit)
+		if err != nil || got != test.want {
+			t.Errorf("%s:\n got %s\nwant %s (%v)", test.format, got, test.want, err)
+		}
+	}
+
+	data, err := renderer.Render(FormatJSONLD, FromMedia(&video))
+	if err != nil {
+		t.Fatalf("Error rendering JSON-LD: %v", err)
+	}
+	if strings.Contains(data, "<b>") {
+		t.Errorf("Expected HTML characters to be escaped in JSON-LD: %s", data)
+	}
+	var object map[string]any
+	if err := json.Unmarshal([]byte(data), &object); err != nil {
+		t.Fatalf("Error decoding JSON-LD: %v", err)
+	}
+	if object["@type"] != "VideoObject" || object["thumbnailUrl"] != video.Image || object["license"] != LicenseURL ||
+		object["creator"].(map[string]any)["url"] != video.User.URL {
+		t.Errorf("Unexpected JSON-LD: %s", data)
+	}
+
+	if _, err := renderer.Render("rtf", credit); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+// Test locales, custom messages and unsafe links
+func TestLocales(t *testing.T) {
+	credit := FromMedia(&video)
+	if got, want := New(Options{Locale: LocaleFor(types.LocaleDeDE)}).Text(credit), `Video von Joey <b>"Farina"</b> auf Pexels`; got != want {
+		t.Errorf("German credit = %q, want %q", got, want)
+	}
+	if got := LocaleFor("pt-PT"); got != Locales[types.LocalePtBR] {
+		t.Errorf("Expected the Portuguese locale for pt-PT, got %+v", got)
+	}
+	if got := LocaleFor(types.LocaleJaJP); got != English {
+		t.Errorf("Expected English for a locale without messages, got %+v", got)
+	}
+
+	custom := New(Options{Locale: Locale{Video: "🎥 {creator} · {pexels} <3"}})
+	credit.Creator.URL = "javascript:alert(1)"
+	credit.MediaURL = ""
+	got := string(custom.HTML(credit))
+	want := `🎥 Joey &lt;b&gt;&#34;Farina&#34;&lt;/b&gt; · <a href="https://www.pexels.com" rel="noopener noreferrer">Pexels</a> &lt;3`
+	if got != want {
+		t.Errorf("Custom credit:\n got %s\nwant %s", got, want)
+	}
+	if custom.Text(FromMedia(&photo)) != `Photo by Joey <b>"Farina"</b> on Pexels` {
+		t.Error("Expected empty messages to fall back to English")
+	}
+}
+
+// Test the credits page aggregated by creator
+func TestPage(t *testing.T) {
+	other := types.Photo{ID: 1, URL: "https://www.pexels.com/photo/1/", Photographer: "Min An", PhotographerID: 7, PhotographerURL: "https://www.pexels.com/@min-an"}
+	credits, err := Collect(photo, &video, &other, types.NewMediaItem(&photo), &other)
+	if err != nil {
+		t.Fatalf("Error collecting credits: %v", err)
+	}
+
+	groups := Group(credits)
+	if len(groups) != 2 || len(groups[0].Credits) != 2 || len(groups[1].Credits) != 1 {
+		t.Fatalf("Unexpected groups: %+v", groups)
+	}
+
+	renderer := New(Options{})
+	markdown, err := renderer.Page(FormatMarkdown, credits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "## Credits\n\n" +
+		`- [Joey \<b\>"Farina"\</b\>](https://www.pexels.com/@joey): [Photo 2014422](https://www.pexels.com/photo/2014422/), [Video 857251](https://www.pexels.com/video/857251/)` + "\n" +
+		"- [Min An](https://www.pexels.com/@min-an): [Photo 1](https://www.pexels.com/photo/1/)\n"
+	if markdown != want {
+		t.Errorf("Markdown page:\n got %q\nwant %q", markdown, want)
+	}
+
+	text, _ := renderer.Page(FormatText, credits)
+	if !strings.Contains(text, "Min An (https://www.pexels.com/@min-an)\n  Photo 1: https://www.pexels.com/photo/1/\n") {
+		t.Errorf("Unexpected text page: %s", text)
+	}
+	html, _ := renderer.Page(FormatHTML, credits)
+	if strings.Count(html, "<li>") != 2 || strings.Contains(html, "<b>") {
+		t.Errorf("Unexpected HTML page: %s", html)
+	}
+	jsonLD, _ := renderer.Page(FormatJSONLD, credits)
+	var page struct {
+		Graph []map[string]any `json:"@graph"`
+	}
+	if err := json.Unmarshal([]byte(jsonLD), &page); err != nil || len(page.Graph) != 3 {
+		t.Errorf("Unexpected JSON-LD page: %s (%v)", jsonLD, err)
+	}
+
+	// Nil media are skipped instead of dereferenced
+	var nilPhoto *types.Photo
+	var nilVideo *types.Video
+	credits, err = Collect(nilPhoto, types.Media(nilVideo), nil, (*types.MediaItem)(nil), &other)
+	if err != nil || len(credits) != 1 || credits[0].MediaID != other.ID {
+		t.Fatalf("Expected only the credit of the non-nil photo, got %+v, %v", credits, err)
+	}
+	if credit := FromMedia(nilPhoto); credit != (Credit{}) {
+		t.Errorf("Expected the zero credit for a nil photo, got %+v", credit)
+	}
+
+	if _, err := Collect("photo"); err == nil {
+		t.Error("Expected an error for an unsupported value")
+	}
+	if _, err := Collect(types.MediaItem{Type: "Gif"}); err == nil {
+		t.Error("Expected an error for an unknown media type")
+	}
+}
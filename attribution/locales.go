@@ -0,0 +1,118 @@
+package attribution
+
+import (
+	"strings"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// Placeholders expanded in the messages of a Locale.
+const (
+	PlaceholderCreator = "{creator}" // Name of the photographer or videographer, linked to their profile
+	PlaceholderPexels  = "{pexels}"  // "Pexels", linked to the page of the media
+)
+
+// Locale holds the messages of the credits. Messages are templates in which the
+// placeholders are replaced with links in the Markdown and HTML formats, e.g.
+//
+//	attribution.Locale{Photo: "📷 {creator} / {pexels}"}
+//
+// Empty messages fall back to English.
+type Locale struct {
+	Photo      string // Credit line of a photo, e.g. "Photo by {creator} on {pexels}"
+	Video      string // Credit line of a video, e.g. "Video by {creator} on {pexels}"
+	Credits    string // Title of a credits page
+	PhotoLabel string // Label of a photo link on a credits page, followed by the photo ID
+	VideoLabel string // Label of a video link on a credits page, followed by the video ID
+}
+
+// English is the default locale.
+var English = Locale{
+	Photo:      "Photo by {creator} on {pexels}",
+	Video:      "Video by {creator} on {pexels}",
+	Credits:    "Credits",
+	PhotoLabel: "Photo",
+	VideoLabel: "Video",
+}
+
+// Locales holds the built-in locales, keyed by the locales of the API.
+var Locales = map[types.Locale]Locale{
+	types.LocaleEnUS: English,
+	types.LocaleDeDE: {
+		Photo:      "Foto von {creator} auf {pexels}",
+		Video:      "Video von {creator} auf {pexels}",
+		Credits:    "Bildnachweise",
+		PhotoLabel: "Foto",
+		VideoLabel: "Video",
+	},
+	types.LocaleEsES: {
+		Photo:      "Foto de {creator} en {pexels}",
+		Video:      "Vídeo de {creator} en {pexels}",
+		Credits:    "Créditos",
+		PhotoLabel: "Foto",
+		VideoLabel: "Vídeo",
+	},
+	types.LocaleFrFR: {
+		Photo:      "Photo de {creator} sur {pexels}",
+		Video:      "Vidéo de {creator} sur {pexels}",
+		Credits:    "Crédits",
+		PhotoLabel: "Photo",
+		VideoLabel: "Vidéo",
+	},
+	types.LocaleItIT: {
+		Photo:      "Foto di {creator} da {pexels}",
+		Video:      "Video di {creator} da {pexels}",
+		Credits:    "Crediti",
+		PhotoLabel: "Foto",
+		VideoLabel: "Video",
+	},
+	types.LocaleNlNL: {
+		Photo:      "Foto door {creator} op {pexels}",
+		Video:      "Video door {creator} op {pexels}",
+		Credits:    "Credits",
+		PhotoLabel: "Foto",
+		VideoLabel: "Video",
+	},
+	types.LocalePtBR: {
+		Photo:      "Foto de {creator} no {pexels}",
+		Video:      "Vídeo de {creator} no {pexels}",
+		Credits:    "Créditos",
+		PhotoLabel: "Foto",
+		VideoLabel: "Vídeo",
+	},
+}
+
+// LocaleFor returns the built-in locale for locale, falling back to another
+// locale of the same language and then to English.
+func LocaleFor(locale types.Locale) Locale {
+	if l, ok := Locales[locale]; ok {
+		return l
+	}
+	language, _, _ := strings.Cut(string(locale), "-")
+	for key, l := range Locales {
+		if prefix, _, _ := strings.Cut(string(key), "-"); strings.EqualFold(prefix, language) {
+			return l
+		}
+	}
+	return English
+}
+
+// withDefaults fills the empty messages of l from English.
+func (l Locale) withDefaults() Locale {
+	if l.Photo == "" {
+		l.Photo = English.Photo
+	}
+	if l.Video == "" {
+		l.Video = English.Video
+	}
+	if l.Credits == "" {
+		l.Credits = English.Credits
+	}
+	if l.PhotoLabel == "" {
+		l.PhotoLabel = English.PhotoLabel
+	}
+	if l.VideoLabel == "" {
+		l.VideoLabel = English.VideoLabel
+	}
+	return l
+}
@@ -0,0 +1,143 @@
+package attribution
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// CreatorCredits holds the credits of one creator.
+type CreatorCredits struct {
+	Creator types.User
+	Credits []Credit // In order of first appearance, without duplicates
+}
+
+// Collect returns the credits of photos, videos and flattened media items, in order.
+// It accepts types.Media, types.Photo, types.Video and types.MediaItem values and
+// pointers to them; other values, and items of an unknown media type, are an error.
+// Nil values, such as the nil elements of a decoded types.MediaList, are skipped.
+func Collect(values ...any) ([]Credit, error) {
+	credits := make([]Credit, 0, len(values))
+	for i, value := range values {
+		var credit Credit
+		var err error
+		switch v := value.(type) {
+		case nil:
+			continue
+		case types.Media:
+			if isNil(v) {
+				continue
+			}
+			credit = FromMedia(v)
+		case types.Photo:
+			credit = FromMedia(&v)
+		case types.Video:
+			credit = FromMedia(&v)
+		case types.MediaItem:
+			credit, err = FromMediaItem(v)
+		case *types.MediaItem:
+			if v == nil {
+				continue
+			}
+			credit, err = FromMediaItem(*v)
+		default:
+			err = fmt.Errorf("cannot credit value %d of type %T", i, value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		credits = append(credits, credit)
+	}
+	return credits, nil
+}
+
+// Group aggregates credits by creator, in order of first appearance. Creators are
+// identified by their ID, or by their profile URL and name when the ID is unknown.
+// Repeated media of a creator are listed once.
+func Group(credits []Credit) []CreatorCredits {
+	var groups []CreatorCredits
+	index := make(map[string]int)
+	seen := make(map[string]bool)
+	for _, credit := range credits {
+		key := creatorKey(credit.Creator)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, CreatorCredits{Creator: credit.Creator})
+		}
+
+		mediaKey := fmt.Sprintf("%s/%s/%d", key, credit.MediaType, credit.MediaID)
+		if !seen[mediaKey] {
+			seen[mediaKey] = true
+			groups[i].Credits = append(groups[i].Credits, credit)
+		}
+	}
+	return groups
+}
+
+// creatorKey identifies a creator for Group.
+func creatorKey(user types.User) string {
+	if user.ID != 0 {
+		return fmt.Sprintf("id:%d", user.ID)
+	}
+	return "url:" + user.URL + "\x00" + user.Name
+}
+
+// Page renders a credits page listing every creator once, followed by links to their media.
+func (r *Renderer) Page(format Format, credits []Credit) (string, error) {
+	groups := Group(credits)
+	var b strings.Builder
+	switch format {
+	case FormatText:
+		fmt.Fprintf(&b, "%s\n", r.locale.Credits)
+		for _, group := range groups {
+			b.WriteString("\n")
+			b.WriteString(group.Creator.Name)
+			if group.Creator.URL != "" {
+				fmt.Fprintf(&b, " (%s)", group.Creator.URL)
+			}
+			b.WriteString("\n")
+			for _, credit := range group.Credits {
+				fmt.Fprintf(&b, "  %s: %s\n", r.label(credit), pageURL(credit))
+			}
+		}
+	case FormatMarkdown:
+		fmt.Fprintf(&b, "## %s\n\n", escapeMarkdown(r.locale.Credits))
+		for _, group := range groups {
+			links := make([]string, len(group.Credits))
+			for i, credit := range group.Credits {
+				links[i] = markdownLink(r.label(credit), pageURL(credit))
+			}
+			fmt.Fprintf(&b, "- %s: %s\n", markdownLink(group.Creator.Name, group.Creator.URL), strings.Join(links, ", "))
+		}
+	case FormatHTML:
+		fmt.Fprintf(&b, `<section class="pexels-credits"><h2>%s</h2><ul>`, template.HTMLEscapeString(r.locale.Credits))
+		for _, group := range groups {
+			links := make([]string, len(group.Credits))
+			for i, credit := range group.Credits {
+				links[i] = r.htmlLink(r.label(credit), pageURL(credit))
+			}
+			fmt.Fprintf(&b, "<li>%s: %s</li>", r.htmlLink(group.Creator.Name, group.Creator.URL), strings.Join(links, ", "))
+		}
+		b.WriteString("</ul></section>")
+	case FormatJSONLD:
+		graph := make([]map[string]any, 0, len(credits))
+		for _, group := range groups {
+			for _, credit := range group.Credits {
+				graph = append(graph, r.jsonLD(credit))
+			}
+		}
+		data, err := json.Marshal(map[string]any{"@context": "https://schema.org", "@graph": graph})
+		if err != nil {
+			return "", err
+		}
+		b.Write(data)
+	default:
+		return "", fmt.Errorf("unknown attribution format %q", format)
+	}
+	return b.String(), nil
+}
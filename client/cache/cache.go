@@ -0,0 +1,174 @@
+// Package cache provides response caches that plug into FetchWrapper so that
+// repeated identical requests are served without spending API quota.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Entry is a cached API response.
+type Entry struct {
+	Key       string      `json:"key"`        // Key the entry is stored under; set by the cache
+	URL       string      `json:"url"`        // Canonical URL the response was fetched from
+	Body      []byte      `json:"body"`       // Raw response body
+	Header    http.Header `json:"header"`     // Response headers
+	FetchedAt time.Time   `json:"fetched_at"` // Time the response was received
+	ExpiresAt time.Time   `json:"expires_at"` // Time after which the entry is stale
+}
+
+// Fresh reports whether the entry has not yet expired at the given time.
+func (e *Entry) Fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// size approximates the memory used by the entry.
+func (e *Entry) size() int64 {
+	n := len(e.URL) + len(e.Body)
+	for key, values := range e.Header {
+		n += len(key)
+		for _, value := range values {
+			n += len(value)
+		}
+	}
+	return int64(n)
+}
+
+// Cache stores API responses keyed by canonical request URL and API key, see ScopedKey.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the fresh entry stored under key, if any.
+	Get(key string) (*Entry, bool)
+
+	// Set stores the entry under key. The cache decides how long the entry
+	// stays fresh and may decide not to store it at all.
+	Set(key string, entry *Entry)
+}
+
+// StaleReader is implemented by caches that can return entries past their expiry,
+// which is what FetchWrapper falls back to in offline mode.
+type StaleReader interface {
+	GetStale(key string) (*Entry, bool)
+}
+
+// Stats reports the effectiveness and size of a cache.
+type Stats struct {
+	Hits      int64 // Lookups answered from the cache
+	Misses    int64 // Lookups that found no fresh entry
+	Evictions int64 // Entries removed to respect the size bounds
+	Entries   int   // Entries currently stored
+	Bytes     int64 // Approximate size of the stored entries
+}
+
+// Key returns the canonical cache key of a request URL: scheme and host are
+// lower-cased and query parameters are sorted, so that equivalent URLs share a key.
+func Key(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.RawQuery = u.Query().Encode()
+	u.Fragment = ""
+	return u.String()
+}
+
+// ScopedKey returns the cache key of a request URL sent with apiKey: the canonical
+// URL of Key followed by a hash of the API key. Responses differ between accounts,
+// e.g. "List your collections" or the liked flag of photos, so a cache shared by
+// several API keys must never answer one account with another's response.
+// The API key itself never appears in the key.
+func ScopedKey(apiKey, rawURL string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return Key(rawURL) + "#" + hex.EncodeToString(sum[:8])
+}
+
+// Class groups endpoints whose responses change at a similar rate.
+type Class string
+
+// Endpoint classes recognized by ClassOf.
+const (
+	ClassSearch          Class = "search"           // Photo and video search results
+	ClassCurated         Class = "curated"          // Curated photos
+	ClassPopular         Class = "popular"          // Popular videos
+	ClassPhoto           Class = "photo"            // A single photo by ID
+	ClassVideo           Class = "video"            // A single video by ID
+	ClassCollections     Class = "collections"      // Collection listings, including featured
+	ClassCollectionMedia Class = "collection_media" // Media of a single collection
+	ClassOther           Class = "other"            // Anything else
+)
+
+// ClassOf determines the endpoint class of a request URL from its path.
+// Only the trailing path segments are inspected so that custom base URLs work.
+func ClassOf(rawURL string) Class {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ClassOther
+	}
+
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return ClassOther
+	}
+	last := segments[len(segments)-1]
+	parent := ""
+	if len(segments) > 1 {
+		parent = segments[len(segments)-2]
+	}
+
+	switch {
+	case last == "search":
+		return ClassSearch
+	case last == "curated":
+		return ClassCurated
+	case last == "popular":
+		return ClassPopular
+	case last == "collections" || last == "featured" && parent == "collections":
+		return ClassCollections
+	case parent == "photos":
+		return ClassPhoto
+	case parent == "videos":
+		return ClassVideo
+	case parent == "collections":
+		return ClassCollectionMedia
+	}
+	return ClassOther
+}
+
+// TTLPolicy determines how long responses of each endpoint class stay fresh.
+// A zero or negative TTL disables caching for the class.
+type TTLPolicy struct {
+	Default time.Duration           // TTL of classes missing from ByClass
+	ByClass map[Class]time.Duration // Per-class TTLs
+}
+
+// DefaultTTLPolicy caches frequently changing listings briefly and
+// individual photos and videos for a day.
+func DefaultTTLPolicy() TTLPolicy {
+	return TTLPolicy{
+		Default: 10 * time.Minute,
+		ByClass: map[Class]time.Duration{
+			ClassSearch:          15 * time.Minute,
+			ClassCurated:         5 * time.Minute,
+			ClassPopular:         5 * time.Minute,
+			ClassPhoto:           24 * time.Hour,
+			ClassVideo:           24 * time.Hour,
+			ClassCollections:     time.Hour,
+			ClassCollectionMedia: time.Hour,
+		},
+	}
+}
+
+// TTL returns the time-to-live of responses fetched from the given URL.
+func (p TTLPolicy) TTL(rawURL string) time.Duration {
+	if ttl, ok := p.ByClass[ClassOf(rawURL)]; ok {
+		return ttl
+	}
+	return p.Default
+}
@@ -0,0 +1,238 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Test that equivalent URLs share a key and are classified by endpoint
+func TestKeyAndClass(t *testing.T) {
+	if Key("HTTPS://API.pexels.com/v1/search?query=cat&page=2#x") != Key("https://api.pexels.com/v1/search?page=2&query=cat") {
+		t.Fatal("Expected equivalent URLs to share a key")
+	}
+	collections := "https://api.pexels.com/v1/collections/?page=1"
+	if ScopedKey("key-a", collections) == ScopedKey("key-b", collections) || ScopedKey("key-a", collections) != ScopedKey("key-a", collections+"#x") {
+		t.Fatal("Expected scoped keys to differ between API keys only")
+	}
+	if strings.Contains(ScopedKey("secret-key", collections), "secret") {
+		t.Fatal("Expected the API key to be hashed")
+	}
+
+	cases := map[string]Class{
+		"https://api.pexels.com/v1/search?query=cat":       ClassSearch,
+		"https://api.pexels.com/v1/curated":                ClassCurated,
+		"https://api.pexels.com/videos/popular":            ClassPopular,
+		"https://api.pexels.com/v1/photos/2014422":         ClassPhoto,
+		"https://api.pexels.com/videos/videos/2499611":     ClassVideo,
+		"https://api.pexels.com/v1/collections/featured":   ClassCollections,
+		"https://api.pexels.com/v1/collections/?page=2":    ClassCollections,
+		"https://api.pexels.com/v1/collections/5qa21sj":    ClassCollectionMedia,
+		"https://api.pexels.com/somewhere/else?query=else": ClassOther,
+	}
+	for rawURL, want := range cases {
+		if got := ClassOf(rawURL); got != want {
+			t.Errorf("ClassOf(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
+
+// Test LRU eviction by entry count and by size
+func TestMemoryEviction(t *testing.T) {
+	m := NewMemory(MemoryOptions{MaxEntries: 2})
+	for _, id := range []string{"1", "2"} {
+		m.Set(id, &Entry{URL: "https://api.pexels.com/v1/photos/" + id})
+	}
+	m.Get("1") // 2 is now the least recently used entry
+	m.Set("3", &Entry{URL: "https://api.pexels.com/v1/photos/3"})
+
+	if _, ok := m.Get("2"); ok {
+		t.Fatal("Expected the least recently used entry to be evicted")
+	}
+	if _, ok := m.Get("1"); !ok {
+		t.Fatal("Expected the recently used entry to be kept")
+	}
+	if stats := m.Stats(); stats.Evictions != 1 || stats.Entries != 2 || stats.Hits != 2 || stats.Misses != 1 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+
+	m = NewMemory(MemoryOptions{MaxBytes: 100})
+	body := make([]byte, 60)
+	m.Set("a", &Entry{URL: "a", Body: body})
+	m.Set("b", &Entry{URL: "b", Body: body})
+	if _, ok := m.Get("a"); ok {
+		t.Fatal("Expected the size bound to evict the first entry")
+	}
+	m.Set("c", &Entry{URL: "c", Body: make([]byte, 200)})
+	if _, ok := m.Get("c"); ok {
+		t.Fatal("Expected an entry larger than the cache not to be stored")
+	}
+}
+
+// Test that entries expire according to the TTL of their class
+func TestMemoryTTL(t *testing.T) {
+	now := time.Now()
+	m := NewMemory(MemoryOptions{})
+	m.now = func() time.Time { return now }
+
+	curated := "https://api.pexels.com/v1/curated"
+	photo := "https://api.pexels.com/v1/photos/1"
+	m.Set(curated, &Entry{URL: curated, FetchedAt: now})
+	m.Set(photo, &Entry{URL: photo, FetchedAt: now})
+
+	now = now.Add(time.Hour)
+	if _, ok := m.Get(curated); ok {
+		t.Fatal("Expected the curated entry to have expired")
+	}
+	if _, ok := m.Get(photo); !ok {
+		t.Fatal("Expected the photo entry to still be fresh")
+	}
+
+	// A zero TTL disables caching for the class
+	m = NewMemory(MemoryOptions{TTL: TTLPolicy{Default: time.Minute, ByClass: map[Class]time.Duration{ClassSearch: 0}}})
+	search := "https://api.pexels.com/v1/search?query=cat"
+	m.Set(search, &Entry{URL: search})
+	if m.Stats().Entries != 0 {
+		t.Fatal("Expected search responses not to be cached")
+	}
+}
+
+// Test concurrent use of the memory cache; run with -race
+func TestMemoryConcurrent(t *testing.T) {
+	m := NewMemory(MemoryOptions{MaxEntries: 10})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("https://api.pexels.com/v1/photos/%d", (i*j)%20)
+				if _, ok := m.Get(key); !ok {
+					m.Set(key, &Entry{URL: key, Body: []byte("{}")})
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if stats := m.Stats(); stats.Hits+stats.Misses != 800 || stats.Entries > 10 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+}
+
+// Test that disk entries survive reopening, expire, and remain readable when stale
+func TestDiskPersistence(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	d, err := NewDisk(DiskOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("Error creating disk cache: %v", err)
+	}
+	d.now = func() time.Time { return now }
+
+	key := Key("https://api.pexels.com/v1/curated?page=1")
+	d.Set(key, &Entry{URL: key, Body: []byte(`{"page":1}`), Header: map[string][]string{"Content-Type": {"application/json"}}, FetchedAt: now})
+
+	// A second instance, as after a restart, sees the entry with its metadata
+	reopened, err := NewDisk(DiskOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("Error reopening disk cache: %v", err)
+	}
+	reopened.now = func() time.Time { return now }
+	entry, ok := reopened.Get(key)
+	if !ok || string(entry.Body) != `{"page":1}` || entry.Header.Get("Content-Type") != "application/json" || !entry.FetchedAt.Equal(now) {
+		t.Fatalf("Unexpected entry after reopening: %+v", entry)
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := reopened.Get(key); ok {
+		t.Fatal("Expected the curated entry to have expired")
+	}
+	if _, ok := reopened.GetStale(key); !ok {
+		t.Fatal("Expected the expired entry to be readable as stale")
+	}
+}
+
+// Test that the disk cache evicts the least recently used entries beyond its size cap
+func TestDiskEviction(t *testing.T) {
+	d, err := NewDisk(DiskOptions{Dir: t.TempDir(), MaxBytes: 1400})
+	if err != nil {
+		t.Fatalf("Error creating disk cache: %v", err)
+	}
+
+	now := time.Now()
+	body := make([]byte, 300) // About 600 bytes once encoded with its metadata
+	for i := 0; i < 3; i++ {
+		now = now.Add(time.Second)
+		d.now = func() time.Time { return now }
+		key := fmt.Sprintf("https://api.pexels.com/v1/photos/%d", i)
+		d.Set(key, &Entry{URL: key, Body: body})
+		if i == 1 {
+			// Touch the first entry so that the second becomes the oldest
+			now = now.Add(time.Second)
+			d.Get("https://api.pexels.com/v1/photos/0")
+		}
+	}
+
+	if _, ok := d.Get("https://api.pexels.com/v1/photos/1"); ok {
+		t.Fatal("Expected the least recently used entry to be evicted")
+	}
+	if stats := d.Stats(); stats.Evictions != 1 || stats.Entries != 2 || stats.Bytes > 1400 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+}
+
+// Test that overwriting an entry replaces its size instead of adding to it
+func TestDiskOverwrite(t *testing.T) {
+	d, err := NewDisk(DiskOptions{Dir: t.TempDir(), MaxBytes: 1400})
+	if err != nil {
+		t.Fatalf("Error creating disk cache: %v", err)
+	}
+	// A fixed time keeps the encoded entries the same size
+	now := time.Now()
+	d.now = func() time.Time { return now }
+
+	key := "https://api.pexels.com/v1/photos/1"
+	d.Set(key, &Entry{URL: key, Body: make([]byte, 300)})
+	size := d.Stats().Bytes
+	for range 5 {
+		d.Set(key, &Entry{URL: key, Body: make([]byte, 300)})
+	}
+	if stats := d.Stats(); stats.Bytes != size || stats.Evictions != 0 {
+		t.Fatalf("Expected %d bytes and no eviction after overwrites, got %+v", size, stats)
+	}
+
+	// A smaller entry shrinks the total
+	d.Set(key, &Entry{URL: key, Body: []byte("{}")})
+	if stats := d.Stats(); stats.Bytes >= size || stats.Bytes <= 0 || stats.Entries != 1 {
+		t.Fatalf("Expected one entry of less than %d bytes, got %+v", size, stats)
+	}
+
+	// Concurrent overwrites and new keys are counted once
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.Set(key, &Entry{URL: key, Body: make([]byte, 300)})
+			d.Set(fmt.Sprintf("%s-%d", key, i%2), &Entry{URL: key, Body: []byte("{}")})
+		}()
+	}
+	wg.Wait()
+	if stats := d.Stats(); stats.Entries != 3 || stats.Evictions != 0 {
+		t.Fatalf("Expected 3 entries, got %+v", stats)
+	}
+	var total int64
+	files, _ := filepath.Glob(filepath.Join(d.Dir(), "*"+entryExt))
+	for _, file := range files {
+		info, _ := os.Stat(file)
+		total += info.Size()
+	}
+	if stats := d.Stats(); stats.Bytes != total {
+		t.Fatalf("Expected %d bytes on disk, got %+v", total, stats)
+	}
+}
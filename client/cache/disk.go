@@ -0,0 +1,297 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDiskMaxBytes is the default size cap of a Disk cache.
+const DefaultDiskMaxBytes = 256 << 20
+
+// dirName is the directory created inside the user cache directory.
+const dirName = "pexels-go"
+
+// entryExt is the file extension of cache entries; temporary files never carry it.
+const entryExt = ".json"
+
+// staleTempAge is how old a leftover temporary file must be before it is removed.
+const staleTempAge = time.Hour
+
+// DefaultDir returns the default location of the disk cache,
+// e.g. $XDG_CACHE_HOME/pexels-go on Linux.
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("error locating the user cache directory: %w", err)
+	}
+	return filepath.Join(base, dirName), nil
+}
+
+// DiskOptions configures a Disk cache. Zero values select the defaults.
+type DiskOptions struct {
+	Dir      string    // Directory holding the entries; defaults to DefaultDir
+	MaxBytes int64     // Size cap; least recently used entries are evicted first
+	TTL      TTLPolicy // Freshness per endpoint class; defaults to DefaultTTLPolicy
+}
+
+// Disk is a persistent cache storing one JSON file per response, including the
+// request URL, fetch time and response headers. Writes go to a temporary file
+// that is renamed into place, so several processes can share a directory.
+// Disk implements StaleReader and is safe for concurrent use.
+type Disk struct {
+	dir      string
+	maxBytes int64
+	ttl      TTLPolicy
+	now      func() time.Time
+
+	mu    sync.Mutex // Also serializes writes, so that their sizes are accounted for exactly
+	bytes int64      // Approximate size of the directory, refreshed on every prune
+	stats Stats
+}
+
+// NewDisk creates the cache directory if needed and returns a cache using it.
+func NewDisk(opts DiskOptions) (*Disk, error) {
+	if opts.Dir == "" {
+		dir, err := DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+		opts.Dir = dir
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultDiskMaxBytes
+	}
+	if opts.TTL.Default == 0 && opts.TTL.ByClass == nil {
+		opts.TTL = DefaultTTLPolicy()
+	}
+
+	if err := os.MkdirAll(opts.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating cache directory: %w", err)
+	}
+
+	d := &Disk{
+		dir:      opts.Dir,
+		maxBytes: opts.MaxBytes,
+		ttl:      opts.TTL,
+		now:      time.Now,
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.prune(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Dir returns the directory holding the cache entries.
+func (d *Disk) Dir() string {
+	return d.dir
+}
+
+// Get returns the fresh entry stored under key.
+func (d *Disk) Get(key string) (*Entry, bool) {
+	entry, ok := d.read(key)
+	fresh := ok && entry.Fresh(d.now())
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !fresh {
+		d.stats.Misses++
+		return nil, false
+	}
+	d.stats.Hits++
+	return entry, true
+}
+
+// GetStale returns the entry stored under key even if it has expired.
+func (d *Disk) GetStale(key string) (*Entry, bool) {
+	entry, ok := d.read(key)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !ok {
+		d.stats.Misses++
+		return nil, false
+	}
+	d.stats.Hits++
+	return entry, true
+}
+
+// Set atomically writes the entry under key with the TTL of its endpoint class,
+// evicting least recently used entries when the directory exceeds the size cap.
+// Write failures are ignored; the cache is best effort. When the directory cannot
+// be read to evict entries, the size is left over the cap so that the next Set
+// tries again.
+func (d *Disk) Set(key string, entry *Entry) {
+	ttl := d.ttl.TTL(entry.URL)
+	if ttl <= 0 {
+		return
+	}
+
+	stored := *entry
+	stored.Key = key
+	if stored.FetchedAt.IsZero() {
+		stored.FetchedAt = d.now()
+	}
+	stored.ExpiresAt = stored.FetchedAt.Add(ttl)
+
+	data, err := json.Marshal(&stored)
+	if err != nil || int64(len(data)) > d.maxBytes {
+		return
+	}
+
+	// The size of an overwritten entry is read under the lock, so that concurrent
+	// writers of the same key never both subtract it
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	path := d.path(key)
+	replaced, exists := int64(0), false
+	if info, err := os.Stat(path); err == nil {
+		replaced, exists = info.Size(), true
+	}
+	if err := d.write(path, data); err != nil {
+		return
+	}
+
+	d.bytes += int64(len(data)) - replaced
+	if !exists {
+		d.stats.Entries++
+	}
+	if d.bytes > d.maxBytes {
+		// On failure d.bytes stays over the cap, and the next Set prunes again
+		_ = d.prune()
+	}
+}
+
+// Stats returns a snapshot of the cache statistics. Entries and Bytes follow the
+// writes of this process and are recomputed from the directory on every eviction
+// pass, which also picks up the entries written by other processes.
+func (d *Disk) Stats() Stats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stats := d.stats
+	stats.Bytes = d.bytes
+	return stats
+}
+
+// Purge removes every entry from the cache directory.
+func (d *Disk) Purge() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return fmt.Errorf("error reading cache directory: %w", err)
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), entryExt) {
+			os.Remove(filepath.Join(d.dir, file.Name()))
+		}
+	}
+	d.bytes = 0
+	d.stats.Entries = 0
+	return nil
+}
+
+// path returns the file holding the entry for key.
+func (d *Disk) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+entryExt)
+}
+
+// read loads the entry stored under key and marks it as recently used.
+func (d *Disk) read(key string) (*Entry, bool) {
+	path := d.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry Entry
+	// The key guards against hash collisions and entries written by older versions
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return nil, false
+	}
+
+	// The modification time orders entries for eviction
+	now := d.now()
+	os.Chtimes(path, now, now)
+	return &entry, true
+}
+
+// write stores data at path through a temporary file in the same directory,
+// so that readers never observe a partially written entry.
+func (d *Disk) write(path string, data []byte) error {
+	tmp, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// prune recomputes the size of the directory and removes the least recently
+// used entries until it fits within the cap. The caller holds the lock.
+func (d *Disk) prune() error {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return fmt.Errorf("error reading cache directory: %w", err)
+	}
+
+	type cachedFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var entries []cachedFile
+	var total int64
+	for _, file := range files {
+		info, err := file.Info()
+		if err != nil || info.IsDir() {
+			continue
+		}
+		path := filepath.Join(d.dir, file.Name())
+
+		// Remove temporary files left behind by crashed writers
+		if !strings.HasSuffix(file.Name(), entryExt) {
+			if strings.HasPrefix(file.Name(), ".tmp-") && d.now().Sub(info.ModTime()) > staleTempAge {
+				os.Remove(path)
+			}
+			continue
+		}
+
+		entries = append(entries, cachedFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+	}
+
+	// Oldest entries first
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modTime.Before(entries[j].modTime) })
+	for len(entries) > 0 && total > d.maxBytes {
+		if err := os.Remove(entries[0].path); err == nil || os.IsNotExist(err) {
+			total -= entries[0].size
+			d.stats.Evictions++
+		}
+		entries = entries[1:]
+	}
+
+	d.bytes = total
+	d.stats.Entries = len(entries)
+	return nil
+}
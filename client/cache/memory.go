@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Default bounds of a Memory cache.
+const (
+	DefaultMaxEntries = 1000     // Maximum number of entries kept in memory
+	DefaultMaxBytes   = 64 << 20 // Maximum approximate size of the entries kept in memory
+)
+
+// MemoryOptions configures a Memory cache. Zero values select the defaults.
+type MemoryOptions struct {
+	MaxEntries int       // Maximum number of entries; least recently used entries are evicted first
+	MaxBytes   int64     // Maximum approximate size of all entries
+	TTL        TTLPolicy // Freshness per endpoint class; defaults to DefaultTTLPolicy
+}
+
+// Memory is an in-memory LRU cache bounded by entry count and size.
+// It is safe for concurrent use.
+type Memory struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	ttl        TTLPolicy
+	now        func() time.Time
+
+	order *list.List               // Most recently used entries at the front
+	items map[string]*list.Element // Elements hold *memoryItem
+	bytes int64
+	stats Stats
+}
+
+// memoryItem is the value stored in the LRU list.
+type memoryItem struct {
+	key   string
+	entry *Entry
+	size  int64
+}
+
+// NewMemory creates an in-memory cache with the given options.
+func NewMemory(opts MemoryOptions) *Memory {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = DefaultMaxEntries
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultMaxBytes
+	}
+	if opts.TTL.Default == 0 && opts.TTL.ByClass == nil {
+		opts.TTL = DefaultTTLPolicy()
+	}
+
+	return &Memory{
+		maxEntries: opts.MaxEntries,
+		maxBytes:   opts.MaxBytes,
+		ttl:        opts.TTL,
+		now:        time.Now,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get returns the fresh entry stored under key and marks it as recently used.
+// Expired entries are dropped.
+func (m *Memory) Get(key string) (*Entry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.items[key]
+	if !ok {
+		m.stats.Misses++
+		return nil, false
+	}
+
+	item := elem.Value.(*memoryItem)
+	if !item.entry.Fresh(m.now()) {
+		m.remove(elem)
+		m.stats.Misses++
+		return nil, false
+	}
+
+	m.order.MoveToFront(elem)
+	m.stats.Hits++
+	return item.entry, true
+}
+
+// Set stores the entry under key with the TTL of its endpoint class,
+// evicting least recently used entries to stay within the bounds.
+// Entries of classes with no TTL, or larger than the whole cache, are not stored.
+func (m *Memory) Set(key string, entry *Entry) {
+	ttl := m.ttl.TTL(entry.URL)
+	if ttl <= 0 {
+		return
+	}
+
+	stored := *entry
+	stored.Key = key
+	stored.ExpiresAt = stored.FetchedAt.Add(ttl)
+	if stored.FetchedAt.IsZero() {
+		stored.ExpiresAt = m.now().Add(ttl)
+	}
+	size := stored.size()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if size > m.maxBytes {
+		return
+	}
+	if elem, ok := m.items[key]; ok {
+		m.remove(elem)
+	}
+
+	m.items[key] = m.order.PushFront(&memoryItem{key: key, entry: &stored, size: size})
+	m.bytes += size
+
+	for m.order.Len() > m.maxEntries || m.bytes > m.maxBytes {
+		m.remove(m.order.Back())
+		m.stats.Evictions++
+	}
+}
+
+// Stats returns a snapshot of the cache statistics.
+func (m *Memory) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.stats
+	stats.Entries = m.order.Len()
+	stats.Bytes = m.bytes
+	return stats
+}
+
+// Purge removes every entry while keeping the statistics.
+func (m *Memory) Purge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.order.Init()
+	m.items = make(map[string]*list.Element)
+	m.bytes = 0
+}
+
+// remove deletes an element from the list and the index. The caller holds the lock.
+func (m *Memory) remove(elem *list.Element) {
+	item := m.order.Remove(elem).(*memoryItem)
+	delete(m.items, item.key)
+	m.bytes -= item.size
+}
@@ -1,7 +1,8 @@
 package client
 
 import (
-	"log"
+	"log/slog"
+	"net/http"
 
 	"github.com/kumarsgoyal/pexels-go/client/endpoints"
 	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
@@ -15,33 +16,106 @@ const (
 )
 
 // PexelsClient serves as the central client for interacting with the Pexels API.
-// It holds references to individual service endpoints for Photos, Videos, and Collections.
+// It holds references to individual services for Photos, Videos, and Collections.
+// The services are interfaces so that they can be replaced by fakes in tests.
 type PexelsClient struct {
-	Photos      endpoints.PhotoEndpoints      // Photo-related API operations
-	Videos      endpoints.VideoEndpoints      // Video-related API operations
-	Collections endpoints.CollectionEndpoints // Collection-related API operations
+	Photos      endpoints.PhotoService      // Photo-related API operations
+	Videos      endpoints.VideoService      // Video-related API operations
+	Collections endpoints.CollectionService // Collection-related API operations
+
+	quota         *fetchwrapper.QuotaTracker   // Quota state shared by all endpoint groups
+	fetchWrappers []*fetchwrapper.FetchWrapper // Fetch wrappers of all endpoint groups
 }
 
-// NewClient initializes a new PexelsClient with the given API key.
-// It sets up fetch wrappers for each type of service (photos, videos, collections).
-func NewClient(apiKey string) *PexelsClient {
-	log.Println("Initializing Pexels Client...")
+// NewClient initializes a new PexelsClient with the given API key and options.
+// It sets up fetch wrappers for each type of service (photos, videos, collections)
+// that share a single HTTP client, quota tracker and scheduler.
+func NewClient(apiKey string, opts ...Option) *PexelsClient {
+	// Apply the options on top of the defaults
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	logger := cfg.buildLogger(apiKey)
+	logger.Debug("initializing Pexels client")
+
+	// The quota is tracked per API key, so all endpoint groups share one tracker
+	quota := fetchwrapper.NewQuotaTracker()
+	quota.SetPolicy(cfg.quotaPolicy)
+	httpClient := cfg.buildHTTPClient()
+
+	// Requests of all endpoint groups count against the same limits
+	var scheduler *fetchwrapper.Scheduler
+	if cfg.scheduler != nil {
+		scheduler = fetchwrapper.NewScheduler(*cfg.scheduler)
+	}
 
 	// Create fetch wrappers with the appropriate base URL and API key
-	photoFetchWrapper := createFetchWrapper(PhotoBaseURL, apiKey)
-	videoFetchWrapper := createFetchWrapper(VideoBaseURL, apiKey)
-	collectionFetchWrapper := createFetchWrapper(CollectionBaseURL, apiKey)
+	photoFetchWrapper := createFetchWrapper(cfg.photoBaseURL, apiKey, httpClient, quota, scheduler, logger, cfg)
+	videoFetchWrapper := createFetchWrapper(cfg.videoBaseURL, apiKey, httpClient, quota, scheduler, logger, cfg)
+	collectionFetchWrapper := createFetchWrapper(cfg.collectionBaseURL, apiKey, httpClient, quota, scheduler, logger, cfg)
+
+	photos := endpoints.NewPhotoEndpoints(photoFetchWrapper)
+	videos := endpoints.NewVideoEndpoints(videoFetchWrapper)
+	collections := endpoints.NewCollectionEndpoints(collectionFetchWrapper)
 
 	// Initialize and return the PexelsClient with specific endpoints
 	return &PexelsClient{
-		Photos:      endpoints.NewPhotoEndpoints(photoFetchWrapper),
-		Videos:      endpoints.NewVideoEndpoints(videoFetchWrapper),
-		Collections: endpoints.NewCollectionEndpoints(collectionFetchWrapper),
+		Photos:        &photos,
+		Videos:        &videos,
+		Collections:   &collections,
+		quota:         quota,
+		fetchWrappers: []*fetchwrapper.FetchWrapper{photoFetchWrapper, videoFetchWrapper, collectionFetchWrapper},
+	}
+}
+
+// RateLimit returns the quota state reported by the most recent API response.
+// The boolean is false until a response carrying rate-limit headers has been received.
+func (c *PexelsClient) RateLimit() (fetchwrapper.RateLimit, bool) {
+	if c.quota == nil {
+		return fetchwrapper.RateLimit{}, false
+	}
+	return c.quota.RateLimit()
+}
+
+// SetQuotaPolicy controls whether requests are sent, refused or delayed
+// once the remaining quota reported by the API reaches zero.
+func (c *PexelsClient) SetQuotaPolicy(policy fetchwrapper.QuotaPolicy) {
+	if c.quota != nil {
+		c.quota.SetPolicy(policy)
+	}
+}
+
+// SetRetryPolicy configures how transient failures are retried by every endpoint group.
+// Passing nil disables retries.
+func (c *PexelsClient) SetRetryPolicy(policy *fetchwrapper.RetryPolicy) {
+	for _, fw := range c.fetchWrappers {
+		fw.Retry = policy
+	}
+}
+
+// SetOffline switches every endpoint group between serving only from the cache
+// and contacting the API.
+func (c *PexelsClient) SetOffline(offline bool) {
+	for _, fw := range c.fetchWrappers {
+		fw.Offline = offline
 	}
 }
 
-// createFetchWrapper is a helper function that constructs a new FetchWrapper
-// with the provided base URL and API key for a specific service.
-func createFetchWrapper(baseURL, apiKey string) *fetchwrapper.FetchWrapper {
-	return fetchwrapper.NewFetchWrapper(baseURL, apiKey)
+// createFetchWrapper is a helper function that constructs a new FetchWrapper for a specific
+// service with the provided base URL and API key, wired to the shared HTTP client, quota tracker,
+// scheduler and logger.
+func createFetchWrapper(baseURL, apiKey string, httpClient *http.Client, quota *fetchwrapper.QuotaTracker, scheduler *fetchwrapper.Scheduler, logger *slog.Logger, cfg *clientConfig) *fetchwrapper.FetchWrapper {
+	fw := fetchwrapper.NewFetchWrapper(baseURL, apiKey)
+	fw.Client = httpClient
+	fw.UserAgent = cfg.userAgent()
+	fw.Quota = quota
+	fw.Scheduler = scheduler
+	fw.Retry = cfg.retry
+	fw.Logger = logger
+	fw.Cache = cfg.cache
+	fw.Offline = cfg.offline
+	fw.Coalesce = cfg.coalesce
+	return fw
 }
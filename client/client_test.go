@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
+	"github.com/kumarsgoyal/pexels-go/pexelstest"
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// countingTransport counts the requests sent through it.
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// requestAll sends one request through each endpoint group.
+func requestAll(t *testing.T, c *PexelsClient) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := c.Photos.CuratedWithContext(ctx, &types.PaginationParams{PerPage: 1}); err != nil {
+		t.Fatalf("Error fetching curated photos: %v", err)
+	}
+	if _, err := c.Videos.PopularWithContext(ctx, &types.VideoFilterParams{}); err != nil {
+		t.Fatalf("Error fetching popular videos: %v", err)
+	}
+	if _, err := c.Collections.FeaturedWithContext(ctx, types.PaginationParams{}); err != nil {
+		t.Fatalf("Error fetching featured collections: %v", err)
+	}
+}
+
+// Test that every endpoint group shares one HTTP client
+func TestSharedHTTPClient(t *testing.T) {
+	c := NewClient("key")
+	if len(c.fetchWrappers) != 3 {
+		t.Fatalf("Expected 3 fetch wrappers, got %d", len(c.fetchWrappers))
+	}
+	for _, fw := range c.fetchWrappers {
+		if fw.Client != c.fetchWrappers[0].Client {
+			t.Fatal("Expected the endpoint groups to share one *http.Client")
+		}
+	}
+	if timeout := c.fetchWrappers[0].Client.Timeout; timeout != fetchwrapper.DefaultTimeout {
+		t.Fatalf("Expected the default timeout, got %v", timeout)
+	}
+	if c.fetchWrappers[0].Coalesce {
+		t.Fatal("Expected request coalescing to be disabled by default")
+	}
+	if c := NewClient("key", WithRequestCoalescing(true)); !c.fetchWrappers[2].Coalesce {
+		t.Fatal("Expected WithRequestCoalescing to enable request coalescing")
+	}
+}
+
+// Test that WithHTTPClient sends every request through the given client without modifying it
+func TestWithHTTPClient(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+
+	transport := &countingTransport{}
+	httpClient := &http.Client{Transport: transport}
+	c := NewClient(server.APIKey, WithBaseURL(server.URL), WithHTTPClient(httpClient), WithTimeout(time.Minute))
+	requestAll(t, c)
+
+	if n := transport.requests.Load(); n != 3 {
+		t.Fatalf("Expected 3 requests through the given client, got %d", n)
+	}
+	if httpClient.Timeout != 0 {
+		t.Fatalf("Expected the given client to be left unmodified, got timeout %v", httpClient.Timeout)
+	}
+	if timeout := c.fetchWrappers[0].Client.Timeout; timeout != time.Minute {
+		t.Fatalf("Expected WithTimeout to apply to the copy, got %v", timeout)
+	}
+}
+
+// Test that WithTransport routes the requests of every endpoint group through the transport
+func TestWithTransport(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+
+	transport := &countingTransport{}
+	c := NewClient(server.APIKey, WithBaseURL(server.URL), WithTransport(transport))
+	requestAll(t, c)
+
+	if n := transport.requests.Load(); n != 3 {
+		t.Fatalf("Expected 3 requests through the transport, got %d", n)
+	}
+}
+
+// Test that WithTimeout aborts requests the server is slow to answer
+func TestWithTimeout(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+	server.SetInterceptor(func(w http.ResponseWriter, r *http.Request) bool {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		return true
+	})
+
+	c := NewClient(server.APIKey, WithBaseURL(server.URL), WithTimeout(50*time.Millisecond))
+	_, err := c.Photos.GetPhotoWithContext(context.Background(), pexelstest.FirstPhotoID)
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("Expected a timeout error, got %v", err)
+	}
+}
+
+// Test that WithUserAgent appends its suffix to the User-Agent of every request
+func TestWithUserAgent(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+
+	var mu sync.Mutex
+	var userAgents []string
+	server.SetInterceptor(func(w http.ResponseWriter, r *http.Request) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		userAgents = append(userAgents, r.UserAgent())
+		return false
+	})
+
+	requestAll(t, NewClient(server.APIKey, WithBaseURL(server.URL), WithUserAgent("my-app/2.1")))
+	want := fetchwrapper.DefaultUserAgent + " my-app/2.1"
+	if len(userAgents) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(userAgents))
+	}
+	for _, userAgent := range userAgents {
+		if userAgent != want {
+			t.Fatalf("Expected User-Agent %q, got %q", want, userAgent)
+		}
+	}
+}
+
+// Test that the per-group base URL options override WithBaseURL for their group only
+func TestBaseURLOptions(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+
+	c := NewClient(server.APIKey, WithBaseURL("http://127.0.0.1:1"), WithPhotoBaseURL(server.URL+"/v1"))
+	if _, err := c.Photos.GetPhotoWithContext(context.Background(), pexelstest.FirstPhotoID); err != nil {
+		t.Fatalf("Expected the photo group to use its base URL: %v", err)
+	}
+	if _, err := c.Videos.GetVideoWithContext(context.Background(), pexelstest.FirstVideoID); err == nil {
+		t.Fatal("Expected the video group to keep the unreachable base URL")
+	}
+
+	c = NewClient(server.APIKey, WithVideoBaseURL(server.URL+"/videos/"), WithCollectionBaseURL(server.URL+"/v1/collections"))
+	if _, err := c.Videos.GetVideoWithContext(context.Background(), pexelstest.FirstVideoID); err != nil {
+		t.Fatalf("Expected the video group to use its base URL: %v", err)
+	}
+	if _, err := c.Collections.FeaturedWithContext(context.Background(), types.PaginationParams{}); err != nil {
+		t.Fatalf("Expected the collection group to use its base URL: %v", err)
+	}
+	if base := c.fetchWrappers[0].BaseURL; base != PhotoBaseURL {
+		t.Fatalf("Expected the photo group to keep the default base URL, got %s", base)
+	}
+
+	requests := server.Requests()
+	want := []string{"/v1/photos/", "/videos/videos/", "/v1/collections/featured"}
+	if len(requests) != len(want) {
+		t.Fatalf("Expected %d requests, got %v", len(want), requests)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(requests[i], prefix) {
+			t.Errorf("Request %d: expected %s..., got %s", i, prefix, requests[i])
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
+	"github.com/kumarsgoyal/pexels-go/internal/parallel"
+)
+
+// DefaultBatchConcurrency is the number of lookups a batch keeps in flight unless configured otherwise.
+const DefaultBatchConcurrency = 4
+
+// BatchOptions configures a GetMany call. A nil *BatchOptions selects the defaults.
+type BatchOptions struct {
+	Concurrency int // Maximum number of lookups in flight; defaults to DefaultBatchConcurrency
+}
+
+// concurrency returns the configured concurrency or the default.
+func (o *BatchOptions) concurrency() int {
+	if o == nil || o.Concurrency <= 0 {
+		return DefaultBatchConcurrency
+	}
+	return o.Concurrency
+}
+
+// ItemResult is the outcome of looking up a single ID.
+type ItemResult[T any] struct {
+	ID   int   // The requested ID
+	Item *T    // The item, or nil when Err is set
+	Err  error // Why the lookup failed, if it did
+}
+
+// NotFound reports whether the lookup failed because the ID does not exist.
+func (r ItemResult[T]) NotFound() bool {
+	return errors.Is(r.Err, fetchwrapper.ErrNotFound)
+}
+
+// BatchResult holds the outcome of every lookup of a GetMany call, in input order.
+type BatchResult[T any] struct {
+	Results []ItemResult[T]
+}
+
+// Items returns the items that were found, in input order.
+func (b *BatchResult[T]) Items() []*T {
+	var items []*T
+	for _, result := range b.Results {
+		if result.Err == nil {
+			items = append(items, result.Item)
+		}
+	}
+	return items
+}
+
+// NotFound returns the IDs that do not exist.
+func (b *BatchResult[T]) NotFound() []int {
+	var ids []int
+	for _, result := range b.Results {
+		if result.NotFound() {
+			ids = append(ids, result.ID)
+		}
+	}
+	return ids
+}
+
+// Failed returns the IDs whose lookup failed for any reason other than not being found,
+// such as a server error, an exhausted quota or a cancelled context. Retrying them may succeed.
+func (b *BatchResult[T]) Failed() []int {
+	var ids []int
+	for _, result := range b.Results {
+		if result.Err != nil && !result.NotFound() {
+			ids = append(ids, result.ID)
+		}
+	}
+	return ids
+}
+
+// Err returns a *BatchError describing the failed lookups, or nil if every lookup succeeded.
+func (b *BatchResult[T]) Err() error {
+	batchErr := &BatchError{Total: len(b.Results)}
+	for _, result := range b.Results {
+		if result.Err == nil {
+			continue
+		}
+		if result.NotFound() {
+			batchErr.NotFound = append(batchErr.NotFound, result.ID)
+		} else {
+			batchErr.Failed = append(batchErr.Failed, result.ID)
+		}
+		batchErr.errs = append(batchErr.errs, result.Err)
+	}
+	if len(batchErr.errs) == 0 {
+		return nil
+	}
+	return batchErr
+}
+
+// BatchError is returned by GetMany when some lookups failed. The results of the
+// successful lookups are still available on the accompanying BatchResult.
+// errors.Is matches it against the errors of the individual lookups.
+type BatchError struct {
+	Total    int   // Number of requested IDs
+	NotFound []int // IDs that do not exist
+	Failed   []int // IDs whose lookup failed for another reason
+
+	errs []error
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("pexels: %d of %d lookups failed (%d not found, %d other errors): %v",
+		len(e.NotFound)+len(e.Failed), e.Total, len(e.NotFound), len(e.Failed), e.errs[0])
+}
+
+// Unwrap returns the errors of the failed lookups.
+func (e *BatchError) Unwrap() []error {
+	return e.errs
+}
+
+// getMany looks up every ID with get, keeping at most the configured number of
+// lookups in flight. Once ctx is done, the remaining IDs fail with ctx.Err().
+func getMany[T any](ctx context.Context, ids []int, opts *BatchOptions, get func(ctx context.Context, id int) (*T, error)) (*BatchResult[T], error) {
+	results := make([]ItemResult[T], len(ids))
+	started := parallel.ForEach(ctx, len(ids), opts.concurrency(), func(i int) {
+		item, err := get(ctx, ids[i])
+		results[i] = ItemResult[T]{ID: ids[i], Item: item, Err: err}
+	})
+	for i := started; i < len(ids); i++ {
+		results[i] = ItemResult[T]{ID: ids[i], Err: ctx.Err()}
+	}
+
+	batch := &BatchResult[T]{Results: results}
+	return batch, batch.Err()
+}
@@ -1,16 +1,20 @@
 package endpoints
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"iter"
+	"log/slog"
+	"net/url"
 
 	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
+	"github.com/kumarsgoyal/pexels-go/query"
 	"github.com/kumarsgoyal/pexels-go/types"
 	"github.com/kumarsgoyal/pexels-go/utils"
 )
 
 const (
-	FeaturedCollectionEndpoint = "/featured"
+	FeaturedCollectionEndpoint = "featured"
 	ParamType                  = "type"
 	ParamSort                  = "sort"
 	ParamPage                  = "page"
@@ -31,14 +35,19 @@ func NewCollectionEndpoints(fetchWrapper *fetchwrapper.FetchWrapper) CollectionE
 // handleError logs and returns a formatted error for a given action.
 // This function helps in handling errors consistently within the CollectionEndpoints methods.
 func (ce *CollectionEndpoints) handleError(action string, err error) error {
-	log.Printf("Error %s: %v", action, err)
+	ce.logger().Debug("collection request failed", "action", action, "error", err)
 	return fmt.Errorf("error %s: %w", action, err)
 }
 
-// prepareCleanedParams removes empty or zero-value parameters from the given map.
-// It helps ensure that only valid parameters are sent to the API.
-func (ce *CollectionEndpoints) prepareCleanedParams(params map[string]interface{}) map[string]interface{} {
-	return utils.CleanParams(params)
+// logger returns the structured logger configured on the FetchWrapper.
+func (ce *CollectionEndpoints) logger() *slog.Logger {
+	return loggerFor(ce.FetchWrapper)
+}
+
+// encodeParams encodes a parameters struct into query values using its url tags.
+// Zero-value optional parameters are left out so that the API defaults apply.
+func (ce *CollectionEndpoints) encodeParams(params any) (url.Values, error) {
+	return query.Encode(params)
 }
 
 // unmarshalResponse unmarshals the given JSON response body into the target structure.
@@ -50,20 +59,26 @@ func (ce *CollectionEndpoints) unmarshalResponse(body []byte, target interface{}
 // All fetches all collections with pagination support. It uses the provided pagination parameters
 // to retrieve the collections in a paginated manner.
 func (ce *CollectionEndpoints) All(params types.PaginationParams) (*types.CollectionsResponse, error) {
-	log.Printf("Fetching collections with page %d and %d per page...", params.Page, params.PerPage)
+	return ce.AllWithContext(context.Background(), params)
+}
 
-	// Prepare query parameters for pagination
-	paramsMap := map[string]interface{}{
-		"page":     params.Page,
-		"per_page": params.PerPage,
+// AllWithContext is like All but uses ctx for cancellation, deadlines and request-scoped values.
+func (ce *CollectionEndpoints) AllWithContext(ctx context.Context, params types.PaginationParams) (*types.CollectionsResponse, error) {
+	ce.logger().Debug("fetching collections", "page", params.Page, "per_page", params.PerPage)
+	if err := params.Validate(); err != nil {
+		return nil, ce.handleError("validating collection parameters", err)
 	}
 
-	cleanedParams := ce.prepareCleanedParams(paramsMap)
+	// Encode the query parameters
+	values, err := ce.encodeParams(params)
+	if err != nil {
+		return nil, ce.handleError("encoding collection parameters", err)
+	}
 
 	// Fetch collections using the FetchWrapper
-	body, err := ce.FetchWrapper.Fetch("", cleanedParams)
+	body, err := ce.FetchWrapper.FetchQuery(ctx, "", values)
 	if err != nil {
-		log.Printf("Error fetching collections: %v", err)
+		ce.logger().Debug("error fetching collections", "error", err)
 		return nil, fmt.Errorf("error fetching collections: %w", err)
 	}
 
@@ -72,27 +87,33 @@ func (ce *CollectionEndpoints) All(params types.PaginationParams) (*types.Collec
 	if err := ce.unmarshalResponse(body, &response); err != nil {
 		return nil, ce.handleError("unmarshaling collections response", err)
 	}
-	log.Printf("Successfully retrieved collections. Total collections: %d", len(response.Collections))
+	ce.logger().Debug("fetched collections", "count", len(response.Collections))
 	return &response, nil
 }
 
 // Featured fetches featured collections with pagination support.
 // It returns a list of featured collections based on the given pagination parameters.
 func (ce *CollectionEndpoints) Featured(params types.PaginationParams) (*types.CollectionsResponse, error) {
-	log.Println("Fetching featured collections...")
+	return ce.FeaturedWithContext(context.Background(), params)
+}
 
-	// Prepare query parameters based on PaginationParams
-	paramsMap := map[string]interface{}{
-		"page":     params.Page,
-		"per_page": params.PerPage,
+// FeaturedWithContext is like Featured but uses ctx for cancellation, deadlines and request-scoped values.
+func (ce *CollectionEndpoints) FeaturedWithContext(ctx context.Context, params types.PaginationParams) (*types.CollectionsResponse, error) {
+	ce.logger().Debug("fetching featured collections")
+	if err := params.Validate(); err != nil {
+		return nil, ce.handleError("validating featured collection parameters", err)
 	}
 
-	cleanedParams := ce.prepareCleanedParams(paramsMap)
+	// Encode the query parameters
+	values, err := ce.encodeParams(params)
+	if err != nil {
+		return nil, ce.handleError("encoding featured collection parameters", err)
+	}
 
 	// Fetch featured collections with pagination
-	body, err := ce.FetchWrapper.Fetch(FeaturedCollectionEndpoint, cleanedParams)
+	body, err := ce.FetchWrapper.FetchQuery(ctx, FeaturedCollectionEndpoint, values)
 	if err != nil {
-		log.Printf("Error fetching featured collections: %v", err)
+		ce.logger().Debug("error fetching featured collections", "error", err)
 		return nil, err
 	}
 
@@ -101,29 +122,33 @@ func (ce *CollectionEndpoints) Featured(params types.PaginationParams) (*types.C
 	if err := ce.unmarshalResponse(body, &response); err != nil {
 		return nil, ce.handleError("unmarshaling featured collections response", err)
 	}
-	log.Printf("Successfully retrieved featured collections.")
+	ce.logger().Debug("fetched featured collections", "count", len(response.Collections))
 	return &response, nil
 }
 
 // Media fetches media (photos or videos) from a specific collection.
 // It allows filtering by media type (photos or videos), sort order, and pagination.
 func (ce *CollectionEndpoints) Media(params types.MediaParams) (*types.MediaResponse, error) {
-	log.Printf("Fetching media for collection ID: %s", params.CollectionID)
+	return ce.MediaWithContext(context.Background(), params)
+}
 
-	// Prepare query parameters
-	paramsMap := map[string]interface{}{
-		"type":     params.MediaType,          // Specify media type (photos or videos)
-		"sort":     params.Sort,               // Specify sort order (asc or desc)
-		"page":     params.Pagination.Page,    // Pagination page
-		"per_page": params.Pagination.PerPage, // Pagination per_page
+// MediaWithContext is like Media but uses ctx for cancellation, deadlines and request-scoped values.
+func (ce *CollectionEndpoints) MediaWithContext(ctx context.Context, params types.MediaParams) (*types.MediaResponse, error) {
+	ce.logger().Debug("fetching collection media", "collection_id", params.CollectionID)
+	if err := params.Validate(); err != nil {
+		return nil, ce.handleError("validating media parameters", err)
 	}
 
-	cleanedParams := ce.prepareCleanedParams(paramsMap)
+	// Encode the query parameters
+	values, err := ce.encodeParams(params)
+	if err != nil {
+		return nil, ce.handleError("encoding media parameters", err)
+	}
 
 	// Fetch media for the collection with pagination and filters
-	body, err := ce.FetchWrapper.Fetch(params.CollectionID, cleanedParams)
+	body, err := ce.FetchWrapper.FetchQuery(ctx, params.CollectionID, values)
 	if err != nil {
-		log.Printf("Error fetching media for collection ID %s: %v", params.CollectionID, err)
+		ce.logger().Debug("error fetching collection media", "collection_id", params.CollectionID, "error", err)
 		return nil, fmt.Errorf("error fetching media: %w", err)
 	}
 
@@ -133,6 +158,113 @@ func (ce *CollectionEndpoints) Media(params types.MediaParams) (*types.MediaResp
 		return nil, ce.handleError("unmarshaling media response", err)
 	}
 
-	log.Printf("Successfully retrieved media for collection ID: %s", params.CollectionID)
+	ce.logger().Debug("fetched collection media", "collection_id", params.CollectionID, "count", len(response.Media))
+	return &response, nil
+}
+
+// ListAll returns an iterator over all collections, the paginated counterpart of All.
+// When maxItems > 0, at most maxItems collections are yielded.
+func (ce *CollectionEndpoints) ListAll(ctx context.Context, params types.PaginationParams, maxItems int) iter.Seq2[types.Collection, error] {
+	return paginate(ctx, maxItems, pager[types.Collection, types.CollectionsResponse]{
+		first: func(ctx context.Context) (*types.CollectionsResponse, error) {
+			return ce.AllWithContext(ctx, params)
+		},
+		next:  ce.NextPage,
+		items: func(page *types.CollectionsResponse) []types.Collection { return page.Collections },
+	})
+}
+
+// FeaturedAll returns an iterator over all featured collections.
+// When maxItems > 0, at most maxItems collections are yielded.
+func (ce *CollectionEndpoints) FeaturedAll(ctx context.Context, params types.PaginationParams, maxItems int) iter.Seq2[types.Collection, error] {
+	return paginate(ctx, maxItems, pager[types.Collection, types.CollectionsResponse]{
+		first: func(ctx context.Context) (*types.CollectionsResponse, error) {
+			return ce.FeaturedWithContext(ctx, params)
+		},
+		next:  ce.NextPage,
+		items: func(page *types.CollectionsResponse) []types.Collection { return page.Collections },
+	})
+}
+
+// MediaAll returns an iterator over all media of a collection, each a *types.Photo or a *types.Video.
+// When maxItems > 0, at most maxItems media items are yielded.
+func (ce *CollectionEndpoints) MediaAll(ctx context.Context, params types.MediaParams, maxItems int) iter.Seq2[types.Media, error] {
+	return paginate(ctx, maxItems, pager[types.Media, types.MediaResponse]{
+		first: func(ctx context.Context) (*types.MediaResponse, error) {
+			return ce.MediaWithContext(ctx, params)
+		},
+		next:  ce.NextMediaPage,
+		items: func(page *types.MediaResponse) []types.Media { return page.Media },
+	})
+}
+
+// MediaPhotos fetches one page of the photos of a collection, ignoring params.MediaType.
+func (ce *CollectionEndpoints) MediaPhotos(ctx context.Context, params types.MediaParams) ([]*types.Photo, error) {
+	params.MediaType = types.CollectionMediaPhotos
+	response, err := ce.MediaWithContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return response.Photos(), nil
+}
+
+// MediaVideos fetches one page of the videos of a collection, ignoring params.MediaType.
+func (ce *CollectionEndpoints) MediaVideos(ctx context.Context, params types.MediaParams) ([]*types.Video, error) {
+	params.MediaType = types.CollectionMediaVideos
+	response, err := ce.MediaWithContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return response.Videos(), nil
+}
+
+// NextPage fetches the page of collections linked by the next_page URL of the given response.
+// It returns ErrNoMorePages when the response is the last page.
+func (ce *CollectionEndpoints) NextPage(ctx context.Context, page *types.CollectionsResponse) (*types.CollectionsResponse, error) {
+	if page == nil || page.NextPage == "" {
+		return nil, ErrNoMorePages
+	}
+	return fetchCollectionPage[types.CollectionsResponse](ctx, ce, page.NextPage)
+}
+
+// PrevPage fetches the page of collections linked by the prev_page URL of the given response.
+// It returns ErrNoMorePages when the response is the first page.
+func (ce *CollectionEndpoints) PrevPage(ctx context.Context, page *types.CollectionsResponse) (*types.CollectionsResponse, error) {
+	if page == nil || page.PrevPage == "" {
+		return nil, ErrNoMorePages
+	}
+	return fetchCollectionPage[types.CollectionsResponse](ctx, ce, page.PrevPage)
+}
+
+// NextMediaPage fetches the page of collection media linked by the next_page URL of the given response.
+// It returns ErrNoMorePages when the response is the last page.
+func (ce *CollectionEndpoints) NextMediaPage(ctx context.Context, page *types.MediaResponse) (*types.MediaResponse, error) {
+	if page == nil || page.NextPage == "" {
+		return nil, ErrNoMorePages
+	}
+	return fetchCollectionPage[types.MediaResponse](ctx, ce, page.NextPage)
+}
+
+// PrevMediaPage fetches the page of collection media linked by the prev_page URL of the given response.
+// It returns ErrNoMorePages when the response is the first page.
+func (ce *CollectionEndpoints) PrevMediaPage(ctx context.Context, page *types.MediaResponse) (*types.MediaResponse, error) {
+	if page == nil || page.PrevPage == "" {
+		return nil, ErrNoMorePages
+	}
+	return fetchCollectionPage[types.MediaResponse](ctx, ce, page.PrevPage)
+}
+
+// fetchCollectionPage fetches a page from an absolute URL returned by the API and decodes it as R.
+func fetchCollectionPage[R any](ctx context.Context, ce *CollectionEndpoints, pageURL string) (*R, error) {
+	body, err := ce.FetchWrapper.FetchURL(ctx, pageURL)
+	if err != nil {
+		ce.logger().Debug("error fetching collections page", "url", pageURL, "error", err)
+		return nil, fmt.Errorf("error fetching collections page: %w", err)
+	}
+
+	var response R
+	if err := ce.unmarshalResponse(body, &response); err != nil {
+		return nil, ce.handleError("unmarshaling collections page", err)
+	}
 	return &response, nil
 }
@@ -0,0 +1,17 @@
+package endpoints
+
+import (
+	"log/slog"
+
+	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
+	"github.com/kumarsgoyal/pexels-go/utils"
+)
+
+// loggerFor returns the logger of the given FetchWrapper, or a logger that
+// discards everything when none is configured.
+func loggerFor(fw *fetchwrapper.FetchWrapper) *slog.Logger {
+	if fw == nil || fw.Logger == nil {
+		return utils.DiscardLogger()
+	}
+	return fw.Logger
+}
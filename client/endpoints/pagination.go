@@ -0,0 +1,72 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// ErrNoMorePages is returned when asking for the next or previous page of a
+// response that does not link to one.
+var ErrNoMorePages = errors.New("pexels: no more pages")
+
+// pager describes how to walk a paginated endpoint: how to fetch the first page,
+// how to fetch the page following a given one, and how to extract its items.
+type pager[T any, R any] struct {
+	first func(ctx context.Context) (*R, error)
+	next  func(ctx context.Context, page *R) (*R, error) // Returns ErrNoMorePages after the last page
+	items func(page *R) []T
+}
+
+// Paginate returns an iterator over the items of the page returned by first and of
+// the pages following it, as returned by next, with the semantics of the *All methods.
+// It lets other implementations of the services, such as those of package fakes,
+// iterate exactly like the endpoints do.
+func Paginate[T any, R any](ctx context.Context, maxItems int, first func(ctx context.Context) (*R, error),
+	next func(ctx context.Context, page *R) (*R, error), items func(page *R) []T) iter.Seq2[T, error] {
+	return paginate(ctx, maxItems, pager[T, R]{first: first, next: next, items: items})
+}
+
+// paginate returns an iterator over the items of every page, fetching pages lazily
+// as the loop advances. Iteration stops after maxItems items when maxItems > 0,
+// when the last page has been consumed, or when the loop body breaks.
+// A fetch error is yielded once, together with the zero value of T, and ends the iteration.
+// A nil page without an error counts as the last page.
+func paginate[T any, R any](ctx context.Context, maxItems int, p pager[T, R]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		page, err := p.first(ctx)
+		count := 0
+		for {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if page == nil {
+				return
+			}
+
+			items := p.items(page)
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				count++
+				if maxItems > 0 && count >= maxItems {
+					return
+				}
+			}
+
+			// An empty page means the API has nothing more to offer, even if it linked one
+			if len(items) == 0 {
+				return
+			}
+
+			page, err = p.next(ctx, page)
+			if errors.Is(err, ErrNoMorePages) {
+				return
+			}
+		}
+	}
+}
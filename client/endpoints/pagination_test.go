@@ -0,0 +1,216 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
+	"github.com/kumarsgoyal/pexels-go/pexelstest"
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// newTestEndpoints returns the endpoint groups of a fake API.
+func newTestEndpoints(server *pexelstest.Server) (PhotoEndpoints, VideoEndpoints, CollectionEndpoints) {
+	return NewPhotoEndpoints(fetchwrapper.NewFetchWrapper(server.URL+"/v1/", server.APIKey)),
+		NewVideoEndpoints(fetchwrapper.NewFetchWrapper(server.URL+"/videos/", server.APIKey)),
+		NewCollectionEndpoints(fetchwrapper.NewFetchWrapper(server.URL+"/v1/collections/", server.APIKey))
+}
+
+// consume counts the items of seq, breaking out of the loop after stopAfter items when
+// stopAfter > 0. It returns the error yielded by seq, if any.
+func consume[T any](seq iter.Seq2[T, error], stopAfter int) (int, error) {
+	count := 0
+	for _, err := range seq {
+		if err != nil {
+			return count, err
+		}
+		count++
+		if count == stopAfter {
+			break
+		}
+	}
+	return count, nil
+}
+
+// Test the iterators of every paginated endpoint: maxItems, breaking out of the loop
+// and an error on a middle page
+func TestIterators(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+	photos, videos, collections := newTestEndpoints(server)
+	ctx := context.Background()
+
+	// Every iterator walks pages of 5 items
+	tests := []struct {
+		name string
+		run  func(maxItems, stopAfter int) (int, error)
+	}{
+		{"SearchAll", func(maxItems, stopAfter int) (int, error) {
+			return consume(photos.SearchAll(ctx, &types.PhotoSearchParams{Query: "cats", PerPage: 5}, maxItems), stopAfter)
+		}},
+		{"CuratedAll", func(maxItems, stopAfter int) (int, error) {
+			return consume(photos.CuratedAll(ctx, &types.PaginationParams{PerPage: 5}, maxItems), stopAfter)
+		}},
+		{"videos SearchAll", func(maxItems, stopAfter int) (int, error) {
+			return consume(videos.SearchAll(ctx, &types.VideoSearchParams{Query: "ocean", PerPage: 5}, maxItems), stopAfter)
+		}},
+		{"PopularAll", func(maxItems, stopAfter int) (int, error) {
+			return consume(videos.PopularAll(ctx, &types.VideoFilterParams{PerPage: 5}, maxItems), stopAfter)
+		}},
+		{"ListAll", func(maxItems, stopAfter int) (int, error) {
+			return consume(collections.ListAll(ctx, types.PaginationParams{PerPage: 5}, maxItems), stopAfter)
+		}},
+		{"FeaturedAll", func(maxItems, stopAfter int) (int, error) {
+			return consume(collections.FeaturedAll(ctx, types.PaginationParams{PerPage: 5}, maxItems), stopAfter)
+		}},
+		{"MediaAll", func(maxItems, stopAfter int) (int, error) {
+			params := types.MediaParams{CollectionID: pexelstest.FirstCollectionID, Pagination: types.PaginationParams{PerPage: 5}}
+			return consume(collections.MediaAll(ctx, params, maxItems), stopAfter)
+		}},
+	}
+
+	for _, test := range tests {
+		server.SetInterceptor(nil)
+
+		// maxItems stops in the middle of the second page, without fetching a third one
+		before := server.RequestCount()
+		if count, err := test.run(7, 0); err != nil || count != 7 {
+			t.Errorf("%s: maxItems 7: got %d items, error %v", test.name, count, err)
+		}
+		if requests := server.RequestCount() - before; requests != 2 {
+			t.Errorf("%s: maxItems 7: expected 2 requests, got %d", test.name, requests)
+		}
+
+		// Breaking out of the loop at the end of a page stops further requests
+		before = server.RequestCount()
+		if count, err := test.run(0, 5); err != nil || count != 5 {
+			t.Errorf("%s: break: got %d items, error %v", test.name, count, err)
+		}
+		if requests := server.RequestCount() - before; requests != 1 {
+			t.Errorf("%s: break: expected 1 request, got %d", test.name, requests)
+		}
+
+		// An error on the second page is yielded after the items of the first page
+		server.SetInterceptor(func(w http.ResponseWriter, r *http.Request) bool {
+			if r.URL.Query().Get("page") != "2" {
+				return false
+			}
+			http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+			return true
+		})
+		before = server.RequestCount()
+		count, err := test.run(0, 0)
+		if count != 5 || !errors.Is(err, fetchwrapper.ErrServer) {
+			t.Errorf("%s: page error: got %d items, error %v", test.name, count, err)
+		}
+		if requests := server.RequestCount() - before; requests != 2 {
+			t.Errorf("%s: page error: expected 2 requests, got %d", test.name, requests)
+		}
+	}
+}
+
+// Test that an error on the first page is yielded once, without an item, and ends the iteration
+func TestIteratorFirstPageError(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+	photos, _, _ := newTestEndpoints(server)
+	photos.FetchWrapper.APIKey = "wrong"
+
+	errs := 0
+	for photo, err := range photos.CuratedAll(context.Background(), nil, 0) {
+		if err == nil || photo.ID != 0 {
+			t.Fatalf("Expected only an error, got photo %d and error %v", photo.ID, err)
+		}
+		if !strings.Contains(err.Error(), "curated") || !errors.Is(err, fetchwrapper.ErrUnauthorized) {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		errs++
+	}
+	if errs != 1 || server.RequestCount() != 1 {
+		t.Fatalf("Expected one error after one request, got %d errors and %d requests", errs, server.RequestCount())
+	}
+}
+
+// Test following absolute next_page and prev_page links, stopping at the first and
+// last pages without a request, and refusing links to another host
+func TestPageLinks(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+	photos, videos, collections := newTestEndpoints(server)
+	ctx := context.Background()
+
+	first, err := photos.CuratedWithContext(ctx, &types.PaginationParams{PerPage: 40})
+	if err != nil {
+		t.Fatalf("Error fetching curated photos: %v", err)
+	}
+	if !strings.HasPrefix(first.NextPage, server.URL+"/v1/curated?") {
+		t.Fatalf("Expected an absolute next_page URL, got %q", first.NextPage)
+	}
+	second, err := photos.NextPage(ctx, first)
+	if err != nil || second.Page != 2 || second.Photos[0].ID != pexelstest.FirstPhotoID+40 {
+		t.Fatalf("Unexpected next page: %+v, error %v", second, err)
+	}
+	if back, err := photos.PrevPage(ctx, second); err != nil || back.Page != 1 {
+		t.Fatalf("Unexpected previous page: %+v, error %v", back, err)
+	}
+	last, err := photos.CuratedWithContext(ctx, &types.PaginationParams{Page: 3, PerPage: 40})
+	if err != nil {
+		t.Fatalf("Error fetching the last page: %v", err)
+	}
+
+	video, err := videos.PopularWithContext(ctx, &types.VideoFilterParams{PerPage: 80})
+	if err != nil {
+		t.Fatalf("Error fetching popular videos: %v", err)
+	}
+	featured, err := collections.FeaturedWithContext(ctx, types.PaginationParams{})
+	if err != nil {
+		t.Fatalf("Error fetching featured collections: %v", err)
+	}
+	media, err := collections.MediaWithContext(ctx, types.MediaParams{CollectionID: pexelstest.FirstCollectionID})
+	if err != nil {
+		t.Fatalf("Error fetching collection media: %v", err)
+	}
+
+	// No link, or no page at all: nothing to fetch
+	requests := server.RequestCount()
+	ends := []struct {
+		name string
+		call func() (any, error)
+	}{
+		{"photos PrevPage", func() (any, error) { return photos.PrevPage(ctx, first) }},
+		{"photos NextPage", func() (any, error) { return photos.NextPage(ctx, last) }},
+		{"photos NextPage nil", func() (any, error) { return photos.NextPage(ctx, nil) }},
+		{"videos PrevPage", func() (any, error) { return videos.PrevPage(ctx, video) }},
+		{"videos NextPage", func() (any, error) { return videos.NextPage(ctx, video) }},
+		{"collections PrevPage", func() (any, error) { return collections.PrevPage(ctx, featured) }},
+		{"collections NextPage", func() (any, error) { return collections.NextPage(ctx, featured) }},
+		{"PrevMediaPage", func() (any, error) { return collections.PrevMediaPage(ctx, media) }},
+		{"NextMediaPage", func() (any, error) { return collections.NextMediaPage(ctx, media) }},
+	}
+	for _, end := range ends {
+		page, err := end.call()
+		if !errors.Is(err, ErrNoMorePages) || !reflect.ValueOf(page).IsNil() {
+			t.Errorf("%s: expected nil and ErrNoMorePages, got %v and %v", end.name, page, err)
+		}
+	}
+	if server.RequestCount() != requests {
+		t.Fatalf("Expected no request at the first and last pages, got %d", server.RequestCount()-requests)
+	}
+
+	// A link to another host would leak the API key
+	foreign := "https://attacker.example.com/v1/curated?page=2"
+	if page, err := photos.NextPage(ctx, &types.PhotosResponse{NextPage: foreign}); err == nil || page != nil {
+		t.Fatalf("Expected a link to another host to be refused, got %+v", page)
+	}
+	if page, err := collections.NextMediaPage(ctx, &types.MediaResponse{NextPage: strings.Replace(first.NextPage, "http://", "https://", 1)}); err == nil || page != nil {
+		t.Fatalf("Expected a link with another scheme to be refused, got %+v", page)
+	}
+	if server.RequestCount() != requests {
+		t.Fatal("Expected refused links not to be requested")
+	}
+}
@@ -1,10 +1,14 @@
 package endpoints
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"iter"
+	"log/slog"
+	"net/url"
 
 	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
+	"github.com/kumarsgoyal/pexels-go/query"
 	"github.com/kumarsgoyal/pexels-go/types"
 	"github.com/kumarsgoyal/pexels-go/utils"
 )
@@ -26,10 +30,10 @@ func NewPhotoEndpoints(fetchWrapper *fetchwrapper.FetchWrapper) PhotoEndpoints {
 	return PhotoEndpoints{FetchWrapper: fetchWrapper}
 }
 
-// prepareCleanedParams removes empty or zero-value parameters from the given map.
-// It ensures that only valid parameters are sent to the API.
-func (pe *PhotoEndpoints) prepareCleanedParams(params map[string]interface{}) map[string]interface{} {
-	return utils.CleanParams(params)
+// encodeParams encodes a parameters struct into query values using its url tags.
+// Zero-value optional parameters are left out so that the API defaults apply.
+func (pe *PhotoEndpoints) encodeParams(params any) (url.Values, error) {
+	return query.Encode(params)
 }
 
 // unmarshalResponse unmarshals the given JSON response body into the target structure.
@@ -41,34 +45,40 @@ func (pe *PhotoEndpoints) unmarshalResponse(body []byte, target interface{}) err
 // handleError logs and returns a formatted error for a given action.
 // This function helps in handling errors consistently within the PhotoEndpoints methods.
 func (pe *PhotoEndpoints) handleError(action string, err error) error {
-	log.Printf("Error %s: %v", action, err)
+	pe.logger().Debug("photo request failed", "action", action, "error", err)
 	return fmt.Errorf("error %s: %w", action, err)
 }
 
+// logger returns the structured logger configured on the FetchWrapper.
+func (pe *PhotoEndpoints) logger() *slog.Logger {
+	return loggerFor(pe.FetchWrapper)
+}
+
 // Search retrieves photos based on a search query and optional filters such as orientation, size, color, etc.
 // It returns a list of photos matching the search criteria, with pagination support.
 func (pe *PhotoEndpoints) Search(params *types.PhotoSearchParams) (*types.PhotosResponse, error) {
+	return pe.SearchWithContext(context.Background(), params)
+}
+
+// SearchWithContext is like Search but uses ctx for cancellation, deadlines and request-scoped values.
+func (pe *PhotoEndpoints) SearchWithContext(ctx context.Context, params *types.PhotoSearchParams) (*types.PhotosResponse, error) {
 	if params == nil {
 		params = &types.PhotoSearchParams{}
 	}
-
-	// Prepare query parameters
-	paramsMap := map[string]interface{}{
-		"query":       params.Query,       // Search query string
-		"orientation": params.Orientation, // Optional filter for photo orientation
-		"size":        params.Size,        // Optional filter for photo size (small, medium, large)
-		"color":       params.Color,       // Optional filter for photo color
-		"locale":      params.Locale,      // Optional filter for photo locale
-		"page":        params.Page,        // Page number for pagination
-		"per_page":    params.PerPage,     // Number of items per page
+	if err := params.Validate(); err != nil {
+		return nil, pe.handleError("validating search parameters", err)
 	}
 
-	cleanedParams := pe.prepareCleanedParams(paramsMap)
+	// Encode the query parameters
+	values, err := pe.encodeParams(params)
+	if err != nil {
+		return nil, pe.handleError("encoding search parameters", err)
+	}
 
 	// Fetch search results
-	body, err := pe.FetchWrapper.Fetch(SearchPhotoEndpoint, cleanedParams)
+	body, err := pe.FetchWrapper.FetchQuery(ctx, SearchPhotoEndpoint, values)
 	if err != nil {
-		log.Printf("Error fetching search results: %v", err)
+		pe.logger().Debug("error fetching search results", "error", err)
 		return nil, fmt.Errorf("error fetching search results: %w", err)
 	}
 
@@ -78,14 +88,19 @@ func (pe *PhotoEndpoints) Search(params *types.PhotoSearchParams) (*types.Photos
 		return nil, pe.handleError("unmarshaling search response", err)
 	}
 
-	log.Printf("Successfully fetched search photos for query: %s", params.Query)
+	pe.logger().Debug("fetched search photos", "query", params.Query, "count", len(response.Photos))
 	return &response, nil
 }
 
 // Curated fetches a curated list of photos based on pagination parameters.
 // Curated photos are hand-picked by the Pexels team and include high-quality photos for various themes.
 func (pe *PhotoEndpoints) Curated(params *types.PaginationParams) (*types.PhotosResponse, error) {
-	log.Println("Fetching curated photos...")
+	return pe.CuratedWithContext(context.Background(), params)
+}
+
+// CuratedWithContext is like Curated but uses ctx for cancellation, deadlines and request-scoped values.
+func (pe *PhotoEndpoints) CuratedWithContext(ctx context.Context, params *types.PaginationParams) (*types.PhotosResponse, error) {
+	pe.logger().Debug("fetching curated photos")
 
 	// If params are nil, initialize with defaults
 	if params == nil {
@@ -99,19 +114,20 @@ func (pe *PhotoEndpoints) Curated(params *types.PaginationParams) (*types.Photos
 	if params.PerPage == 0 {
 		params.PerPage = 15
 	}
-
-	// Map query parameters for pagination
-	paramsMap := map[string]interface{}{
-		"page":     params.Page,
-		"per_page": params.PerPage,
+	if err := params.Validate(); err != nil {
+		return nil, pe.handleError("validating curated parameters", err)
 	}
 
-	cleanedParams := pe.prepareCleanedParams(paramsMap)
+	// Encode the query parameters
+	values, err := pe.encodeParams(params)
+	if err != nil {
+		return nil, pe.handleError("encoding curated parameters", err)
+	}
 
 	// Fetch curated photos with pagination
-	body, err := pe.FetchWrapper.Fetch(CuratedPhotoEndpoint, cleanedParams)
+	body, err := pe.FetchWrapper.FetchQuery(ctx, CuratedPhotoEndpoint, values)
 	if err != nil {
-		log.Printf("Error fetching curated photos: %v", err)
+		pe.logger().Debug("error fetching curated photos", "error", err)
 		return nil, fmt.Errorf("error fetching curated photos: %w", err)
 	}
 
@@ -121,22 +137,27 @@ func (pe *PhotoEndpoints) Curated(params *types.PaginationParams) (*types.Photos
 		return nil, pe.handleError("unmarshaling curated photos response", err)
 	}
 
-	log.Printf("Successfully fetched curated photos.")
+	pe.logger().Debug("fetched curated photos", "count", len(response.Photos))
 	return &response, nil
 }
 
 // GetPhoto fetches a specific photo by its ID. This function returns detailed information about a photo.
 // It includes metadata such as the photographer's name, photo dimensions, and download links.
 func (pe *PhotoEndpoints) GetPhoto(photoID int) (*types.Photo, error) {
-	log.Printf("Fetching photo with ID: %d", photoID)
+	return pe.GetPhotoWithContext(context.Background(), photoID)
+}
+
+// GetPhotoWithContext is like GetPhoto but uses ctx for cancellation, deadlines and request-scoped values.
+func (pe *PhotoEndpoints) GetPhotoWithContext(ctx context.Context, photoID int) (*types.Photo, error) {
+	pe.logger().Debug("fetching photo", "photo_id", photoID)
 
 	// Construct the endpoint URL to fetch the specific photo by ID
 	endpoint := fmt.Sprintf("%s/%d", PhotoEndpoint, photoID)
 
 	// Fetch the photo data
-	body, err := pe.FetchWrapper.Fetch(endpoint, nil)
+	body, err := pe.FetchWrapper.FetchWithContext(ctx, endpoint, nil)
 	if err != nil {
-		log.Printf("Error fetching photo with ID %d: %v", photoID, err)
+		pe.logger().Debug("error fetching photo", "photo_id", photoID, "error", err)
 		return nil, fmt.Errorf("error fetching photo with ID %d: %w", photoID, err)
 	}
 
@@ -146,6 +167,73 @@ func (pe *PhotoEndpoints) GetPhoto(photoID int) (*types.Photo, error) {
 		return nil, pe.handleError(fmt.Sprintf("unmarshaling photo response for ID %d", photoID), err)
 	}
 
-	log.Printf("Successfully fetched photo: %+v", photo)
+	pe.logger().Debug("fetched photo", "photo_id", photo.ID)
 	return &photo, nil
 }
+
+// GetMany fetches the photos with the given IDs, keeping at most opts.Concurrency
+// lookups in flight. The result holds one entry per ID in input order, even when
+// some lookups fail; the error is then a *BatchError. Lookups go through the
+// FetchWrapper, so its cache, scheduler and retry policy apply to each of them.
+func (pe *PhotoEndpoints) GetMany(ctx context.Context, ids []int, opts *BatchOptions) (*BatchResult[types.Photo], error) {
+	pe.logger().Debug("fetching photos", "count", len(ids))
+	return getMany(ctx, ids, opts, pe.GetPhotoWithContext)
+}
+
+// SearchAll returns an iterator over every photo matching params, fetching pages lazily
+// and following the next_page links returned by the API. When maxItems > 0, at most
+// maxItems photos are yielded. Breaking out of the loop stops further requests.
+func (pe *PhotoEndpoints) SearchAll(ctx context.Context, params *types.PhotoSearchParams, maxItems int) iter.Seq2[types.Photo, error] {
+	return paginate(ctx, maxItems, pager[types.Photo, types.PhotosResponse]{
+		first: func(ctx context.Context) (*types.PhotosResponse, error) {
+			return pe.SearchWithContext(ctx, params)
+		},
+		next:  pe.NextPage,
+		items: func(page *types.PhotosResponse) []types.Photo { return page.Photos },
+	})
+}
+
+// CuratedAll returns an iterator over the curated photos, starting at the page given in params.
+// When maxItems > 0, at most maxItems photos are yielded.
+func (pe *PhotoEndpoints) CuratedAll(ctx context.Context, params *types.PaginationParams, maxItems int) iter.Seq2[types.Photo, error] {
+	return paginate(ctx, maxItems, pager[types.Photo, types.PhotosResponse]{
+		first: func(ctx context.Context) (*types.PhotosResponse, error) {
+			return pe.CuratedWithContext(ctx, params)
+		},
+		next:  pe.NextPage,
+		items: func(page *types.PhotosResponse) []types.Photo { return page.Photos },
+	})
+}
+
+// NextPage fetches the page of photos linked by the next_page URL of the given response.
+// It returns ErrNoMorePages when the response is the last page.
+func (pe *PhotoEndpoints) NextPage(ctx context.Context, page *types.PhotosResponse) (*types.PhotosResponse, error) {
+	if page == nil || page.NextPage == "" {
+		return nil, ErrNoMorePages
+	}
+	return pe.fetchPage(ctx, page.NextPage)
+}
+
+// PrevPage fetches the page of photos linked by the prev_page URL of the given response.
+// It returns ErrNoMorePages when the response is the first page.
+func (pe *PhotoEndpoints) PrevPage(ctx context.Context, page *types.PhotosResponse) (*types.PhotosResponse, error) {
+	if page == nil || page.PrevPage == "" {
+		return nil, ErrNoMorePages
+	}
+	return pe.fetchPage(ctx, page.PrevPage)
+}
+
+// fetchPage fetches and decodes a page of photos from an absolute URL returned by the API.
+func (pe *PhotoEndpoints) fetchPage(ctx context.Context, pageURL string) (*types.PhotosResponse, error) {
+	body, err := pe.FetchWrapper.FetchURL(ctx, pageURL)
+	if err != nil {
+		pe.logger().Debug("error fetching photos page", "url", pageURL, "error", err)
+		return nil, fmt.Errorf("error fetching photos page: %w", err)
+	}
+
+	var response types.PhotosResponse
+	if err := pe.unmarshalResponse(body, &response); err != nil {
+		return nil, pe.handleError("unmarshaling photos page", err)
+	}
+	return &response, nil
+}
@@ -0,0 +1,77 @@
+package endpoints
+
+import (
+	"context"
+	"iter"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// PhotoService describes the photo-related operations of the Pexels API.
+// PhotoEndpoints implements it; code depending on the client can accept the
+// interface instead so that it can be substituted with a fake in tests.
+type PhotoService interface {
+	Search(params *types.PhotoSearchParams) (*types.PhotosResponse, error)
+	SearchWithContext(ctx context.Context, params *types.PhotoSearchParams) (*types.PhotosResponse, error)
+	SearchAll(ctx context.Context, params *types.PhotoSearchParams, maxItems int) iter.Seq2[types.Photo, error]
+
+	Curated(params *types.PaginationParams) (*types.PhotosResponse, error)
+	CuratedWithContext(ctx context.Context, params *types.PaginationParams) (*types.PhotosResponse, error)
+	CuratedAll(ctx context.Context, params *types.PaginationParams, maxItems int) iter.Seq2[types.Photo, error]
+
+	GetPhoto(photoID int) (*types.Photo, error)
+	GetPhotoWithContext(ctx context.Context, photoID int) (*types.Photo, error)
+	GetMany(ctx context.Context, ids []int, opts *BatchOptions) (*BatchResult[types.Photo], error)
+
+	NextPage(ctx context.Context, page *types.PhotosResponse) (*types.PhotosResponse, error)
+	PrevPage(ctx context.Context, page *types.PhotosResponse) (*types.PhotosResponse, error)
+}
+
+// VideoService describes the video-related operations of the Pexels API.
+// VideoEndpoints implements it.
+type VideoService interface {
+	Search(params *types.VideoSearchParams) (*types.VideosResponse, error)
+	SearchWithContext(ctx context.Context, params *types.VideoSearchParams) (*types.VideosResponse, error)
+	SearchAll(ctx context.Context, params *types.VideoSearchParams, maxItems int) iter.Seq2[types.Video, error]
+
+	Popular(params *types.VideoFilterParams) (*types.VideosResponse, error)
+	PopularWithContext(ctx context.Context, params *types.VideoFilterParams) (*types.VideosResponse, error)
+	PopularAll(ctx context.Context, params *types.VideoFilterParams, maxItems int) iter.Seq2[types.Video, error]
+
+	GetVideo(videoID int) (*types.Video, error)
+	GetVideoWithContext(ctx context.Context, videoID int) (*types.Video, error)
+	GetMany(ctx context.Context, ids []int, opts *BatchOptions) (*BatchResult[types.Video], error)
+
+	NextPage(ctx context.Context, page *types.VideosResponse) (*types.VideosResponse, error)
+	PrevPage(ctx context.Context, page *types.VideosResponse) (*types.VideosResponse, error)
+}
+
+// CollectionService describes the collection-related operations of the Pexels API.
+// CollectionEndpoints implements it.
+type CollectionService interface {
+	All(params types.PaginationParams) (*types.CollectionsResponse, error)
+	AllWithContext(ctx context.Context, params types.PaginationParams) (*types.CollectionsResponse, error)
+	ListAll(ctx context.Context, params types.PaginationParams, maxItems int) iter.Seq2[types.Collection, error]
+
+	Featured(params types.PaginationParams) (*types.CollectionsResponse, error)
+	FeaturedWithContext(ctx context.Context, params types.PaginationParams) (*types.CollectionsResponse, error)
+	FeaturedAll(ctx context.Context, params types.PaginationParams, maxItems int) iter.Seq2[types.Collection, error]
+
+	Media(params types.MediaParams) (*types.MediaResponse, error)
+	MediaWithContext(ctx context.Context, params types.MediaParams) (*types.MediaResponse, error)
+	MediaAll(ctx context.Context, params types.MediaParams, maxItems int) iter.Seq2[types.Media, error]
+	MediaPhotos(ctx context.Context, params types.MediaParams) ([]*types.Photo, error)
+	MediaVideos(ctx context.Context, params types.MediaParams) ([]*types.Video, error)
+
+	NextPage(ctx context.Context, page *types.CollectionsResponse) (*types.CollectionsResponse, error)
+	PrevPage(ctx context.Context, page *types.CollectionsResponse) (*types.CollectionsResponse, error)
+	NextMediaPage(ctx context.Context, page *types.MediaResponse) (*types.MediaResponse, error)
+	PrevMediaPage(ctx context.Context, page *types.MediaResponse) (*types.MediaResponse, error)
+}
+
+// Compile-time checks that the endpoints implement the service interfaces.
+var (
+	_ PhotoService      = (*PhotoEndpoints)(nil)
+	_ VideoService      = (*VideoEndpoints)(nil)
+	_ CollectionService = (*CollectionEndpoints)(nil)
+)
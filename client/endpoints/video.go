@@ -1,13 +1,16 @@
 package endpoints
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"iter"
+	"log/slog"
+	"net/url"
 
 	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
+	"github.com/kumarsgoyal/pexels-go/query"
 	"github.com/kumarsgoyal/pexels-go/types"
-	"github.com/kumarsgoyal/pexels-go/utils"
 )
 
 const (
@@ -30,14 +33,19 @@ func NewVideoEndpoints(fetchWrapper *fetchwrapper.FetchWrapper) VideoEndpoints {
 // handleError logs the error with a custom message indicating the action that failed.
 // It returns a formatted error with additional context.
 func (ve *VideoEndpoints) handleError(action string, err error) error {
-	log.Printf("Error %s: %v", action, err)
+	ve.logger().Debug("video request failed", "action", action, "error", err)
 	return fmt.Errorf("error %s: %w", action, err)
 }
 
-// prepareCleanedParams removes empty or zero-value parameters from the given map.
-// This helps to avoid sending unnecessary parameters in the API request.
-func (ve *VideoEndpoints) prepareCleanedParams(params map[string]interface{}) map[string]interface{} {
-	return utils.CleanParams(params)
+// logger returns the structured logger configured on the FetchWrapper.
+func (ve *VideoEndpoints) logger() *slog.Logger {
+	return loggerFor(ve.FetchWrapper)
+}
+
+// encodeParams encodes a parameters struct into query values using its url tags.
+// Zero-value optional parameters are left out so that the API defaults apply.
+func (ve *VideoEndpoints) encodeParams(params any) (url.Values, error) {
+	return query.Encode(params)
 }
 
 // unmarshalResponse unmarshals the given JSON response body into the target structure.
@@ -49,26 +57,28 @@ func (ve *VideoEndpoints) unmarshalResponse(body []byte, target interface{}) err
 // Search searches for videos based on the provided query and optional filters.
 // It returns a list of videos matching the search criteria with pagination support.
 func (ve *VideoEndpoints) Search(params *types.VideoSearchParams) (*types.VideosResponse, error) {
+	return ve.SearchWithContext(context.Background(), params)
+}
+
+// SearchWithContext is like Search but uses ctx for cancellation, deadlines and request-scoped values.
+func (ve *VideoEndpoints) SearchWithContext(ctx context.Context, params *types.VideoSearchParams) (*types.VideosResponse, error) {
 	if params == nil {
 		params = &types.VideoSearchParams{}
 	}
-
-	// Prepare query parameters
-	paramsMap := map[string]interface{}{
-		"query":       params.Query,       // The search query string (e.g., 'nature')
-		"orientation": params.Orientation, // Optional filter for video orientation
-		"size":        params.Size,        // Optional filter for video size (small, medium, large)
-		"locale":      params.Locale,      // Optional filter for video locale
-		"page":        params.Page,        // Page number for pagination
-		"per_page":    params.PerPage,     // Number of items per page
+	if err := params.Validate(); err != nil {
+		return nil, ve.handleError("validating video search parameters", err)
 	}
 
-	cleanedParams := ve.prepareCleanedParams(paramsMap)
+	// Encode the query parameters
+	values, err := ve.encodeParams(params)
+	if err != nil {
+		return nil, ve.handleError("encoding video search parameters", err)
+	}
 
 	// Fetch video search results
-	body, err := ve.FetchWrapper.Fetch(SearchVideoEndpoint, cleanedParams)
+	body, err := ve.FetchWrapper.FetchQuery(ctx, SearchVideoEndpoint, values)
 	if err != nil {
-		log.Printf("Error fetching video search results: %v", err)
+		ve.logger().Debug("error fetching video search results", "error", err)
 		return nil, fmt.Errorf("error fetching video search results: %w", err)
 	}
 
@@ -78,34 +88,36 @@ func (ve *VideoEndpoints) Search(params *types.VideoSearchParams) (*types.Videos
 		return nil, ve.handleError("unmarshaling video search response", err)
 	}
 
-	log.Printf("Successfully fetched video search results for query: %s", params.Query)
+	ve.logger().Debug("fetched video search results", "query", params.Query, "count", len(response.Videos))
 	return &response, nil
 }
 
 // Popular fetches a list of popular videos based on optional filter parameters.
 // This function allows you to retrieve high-quality, trending videos from the Pexels library.
 func (ve *VideoEndpoints) Popular(params *types.VideoFilterParams) (*types.VideosResponse, error) {
+	return ve.PopularWithContext(context.Background(), params)
+}
+
+// PopularWithContext is like Popular but uses ctx for cancellation, deadlines and request-scoped values.
+func (ve *VideoEndpoints) PopularWithContext(ctx context.Context, params *types.VideoFilterParams) (*types.VideosResponse, error) {
 	if params == nil {
 		params = &types.VideoFilterParams{}
 	}
-
-	// Prepare query parameters based on filter criteria
-	paramsMap := map[string]interface{}{
-		"min_width":    params.MinWidth,    // Minimum video width
-		"min_height":   params.MinHeight,   // Minimum video height
-		"min_duration": params.MinDuration, // Minimum video duration in seconds
-		"max_duration": params.MaxDuration, // Maximum video duration in seconds
-		"page":         params.Page,        // Page number for pagination
-		"per_page":     params.PerPage,     // Number of items per page
+	if err := params.Validate(); err != nil {
+		return nil, ve.handleError("validating popular video filters", err)
 	}
 
-	cleanedParams := ve.prepareCleanedParams(paramsMap)
+	// Encode the query parameters
+	values, err := ve.encodeParams(params)
+	if err != nil {
+		return nil, ve.handleError("encoding popular video filters", err)
+	}
 
-	log.Println("Fetching popular videos with filters...")
+	ve.logger().Debug("fetching popular videos")
 	// Fetch popular videos based on filters
-	body, err := ve.FetchWrapper.Fetch(PopularVideoEndpoint, cleanedParams)
+	body, err := ve.FetchWrapper.FetchQuery(ctx, PopularVideoEndpoint, values)
 	if err != nil {
-		log.Printf("Error fetching popular videos: %v", err)
+		ve.logger().Debug("error fetching popular videos", "error", err)
 		return nil, fmt.Errorf("error fetching popular videos: %w", err)
 	}
 
@@ -115,32 +127,103 @@ func (ve *VideoEndpoints) Popular(params *types.VideoFilterParams) (*types.Video
 		return nil, ve.handleError("unmarshaling popular videos response", err)
 	}
 
-	log.Printf("Successfully retrieved popular videos.")
+	ve.logger().Debug("fetched popular videos", "count", len(response.Videos))
 	return &response, nil
 }
 
 // GetVideo fetches detailed information about a specific video by its ID.
 // It returns metadata such as the video's dimensions, duration, and download links.
 func (ve *VideoEndpoints) GetVideo(videoID int) (*types.Video, error) {
-	log.Printf("Fetching details for video ID: %d", videoID)
+	return ve.GetVideoWithContext(context.Background(), videoID)
+}
+
+// GetVideoWithContext is like GetVideo but uses ctx for cancellation, deadlines and request-scoped values.
+func (ve *VideoEndpoints) GetVideoWithContext(ctx context.Context, videoID int) (*types.Video, error) {
+	ve.logger().Debug("fetching video", "video_id", videoID)
 
 	// Construct endpoint URL for a specific video by its ID
 	endpoint := fmt.Sprintf("%s/%d", VideoEndpoint, videoID)
 
 	// Fetch video details
-	body, err := ve.FetchWrapper.Fetch(endpoint, nil)
+	body, err := ve.FetchWrapper.FetchWithContext(ctx, endpoint, nil)
 	if err != nil {
-		log.Printf("Error fetching video details for ID %d: %v", videoID, err)
+		ve.logger().Debug("error fetching video", "video_id", videoID, "error", err)
 		return nil, fmt.Errorf("error fetching video details for ID %d: %w", videoID, err)
 	}
 
 	// Parse the response into a Video struct
 	var video types.Video
 	if err := ve.unmarshalResponse(body, &video); err != nil {
-		log.Printf("Error unmarshaling video details for ID %d: %v", videoID, err)
+		ve.logger().Debug("error unmarshaling video", "video_id", videoID, "error", err)
 		return nil, fmt.Errorf("error unmarshaling video details for ID %d: %w", videoID, err)
 	}
 
-	log.Printf("Successfully retrieved details for video ID: %d", videoID)
+	ve.logger().Debug("fetched video", "video_id", videoID)
 	return &video, nil
 }
+
+// GetMany fetches the videos with the given IDs, keeping at most opts.Concurrency
+// lookups in flight. The result holds one entry per ID in input order, even when
+// some lookups fail; the error is then a *BatchError.
+func (ve *VideoEndpoints) GetMany(ctx context.Context, ids []int, opts *BatchOptions) (*BatchResult[types.Video], error) {
+	ve.logger().Debug("fetching videos", "count", len(ids))
+	return getMany(ctx, ids, opts, ve.GetVideoWithContext)
+}
+
+// SearchAll returns an iterator over every video matching params, fetching pages lazily
+// and following the next_page links returned by the API. When maxItems > 0, at most
+// maxItems videos are yielded. Breaking out of the loop stops further requests.
+func (ve *VideoEndpoints) SearchAll(ctx context.Context, params *types.VideoSearchParams, maxItems int) iter.Seq2[types.Video, error] {
+	return paginate(ctx, maxItems, pager[types.Video, types.VideosResponse]{
+		first: func(ctx context.Context) (*types.VideosResponse, error) {
+			return ve.SearchWithContext(ctx, params)
+		},
+		next:  ve.NextPage,
+		items: func(page *types.VideosResponse) []types.Video { return page.Videos },
+	})
+}
+
+// PopularAll returns an iterator over the popular videos matching the filters in params.
+// When maxItems > 0, at most maxItems videos are yielded.
+func (ve *VideoEndpoints) PopularAll(ctx context.Context, params *types.VideoFilterParams, maxItems int) iter.Seq2[types.Video, error] {
+	return paginate(ctx, maxItems, pager[types.Video, types.VideosResponse]{
+		first: func(ctx context.Context) (*types.VideosResponse, error) {
+			return ve.PopularWithContext(ctx, params)
+		},
+		next:  ve.NextPage,
+		items: func(page *types.VideosResponse) []types.Video { return page.Videos },
+	})
+}
+
+// NextPage fetches the page of videos linked by the next_page URL of the given response.
+// It returns ErrNoMorePages when the response is the last page.
+func (ve *VideoEndpoints) NextPage(ctx context.Context, page *types.VideosResponse) (*types.VideosResponse, error) {
+	if page == nil || page.NextPage == "" {
+		return nil, ErrNoMorePages
+	}
+	return ve.fetchPage(ctx, page.NextPage)
+}
+
+// PrevPage fetches the page of videos linked by the prev_page URL of the given response.
+// It returns ErrNoMorePages when the response is the first page.
+func (ve *VideoEndpoints) PrevPage(ctx context.Context, page *types.VideosResponse) (*types.VideosResponse, error) {
+	if page == nil || page.PrevPage == "" {
+		return nil, ErrNoMorePages
+	}
+	return ve.fetchPage(ctx, page.PrevPage)
+}
+
+// fetchPage fetches and decodes a page of videos from an absolute URL returned by the API.
+func (ve *VideoEndpoints) fetchPage(ctx context.Context, pageURL string) (*types.VideosResponse, error) {
+	body, err := ve.FetchWrapper.FetchURL(ctx, pageURL)
+	if err != nil {
+		ve.logger().Debug("error fetching videos page", "url", pageURL, "error", err)
+		return nil, fmt.Errorf("error fetching videos page: %w", err)
+	}
+
+	var response types.VideosResponse
+	if err := ve.unmarshalResponse(body, &response); err != nil {
+		return nil, ve.handleError("unmarshaling videos page", err)
+	}
+	return &response, nil
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"github.com/kumarsgoyal/pexels-go/client/endpoints"
+	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// APIError is the structured error returned for non-OK API responses.
+// Use errors.As to retrieve it from an error returned by any endpoint method.
+type APIError = fetchwrapper.APIError
+
+// CacheMissError is returned in offline mode for requests that are not cached.
+type CacheMissError = fetchwrapper.CacheMissError
+
+// ValidationError is returned, before any request is sent, for invalid parameters.
+type ValidationError = types.ValidationError
+
+// BatchError is returned by GetMany when some of the lookups failed.
+type BatchError = endpoints.BatchError
+
+// Error categories that can be matched with errors.Is against errors returned by the client.
+var (
+	ErrUnauthorized = fetchwrapper.ErrUnauthorized // Missing or invalid API key
+	ErrNotFound     = fetchwrapper.ErrNotFound     // Requested photo, video or collection does not exist
+	ErrRateLimited  = fetchwrapper.ErrRateLimited  // Request quota exhausted
+	ErrServer       = fetchwrapper.ErrServer       // Pexels failed to handle the request
+
+	ErrQuotaExhausted = fetchwrapper.ErrQuotaExhausted // Request refused locally by the quota policy
+	ErrCacheMiss      = fetchwrapper.ErrCacheMiss      // Offline mode and the response is not cached
+	ErrNoMorePages    = endpoints.ErrNoMorePages       // Next or previous page requested on a response without one
+)
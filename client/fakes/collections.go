@@ -0,0 +1,251 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+
+	"github.com/kumarsgoyal/pexels-go/client/endpoints"
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// CollectionsArgs holds the arguments of a call listing collections.
+type CollectionsArgs struct {
+	Ctx    context.Context
+	Params types.PaginationParams
+}
+
+// MediaArgs holds the arguments of a collection media call.
+type MediaArgs struct {
+	Ctx    context.Context
+	Params types.MediaParams
+}
+
+// FakeCollectionService is an in-memory endpoints.CollectionService that records its calls.
+type FakeCollectionService struct {
+	AllStub           func(ctx context.Context, params types.PaginationParams) (*types.CollectionsResponse, error)
+	FeaturedStub      func(ctx context.Context, params types.PaginationParams) (*types.CollectionsResponse, error)
+	MediaStub         func(ctx context.Context, params types.MediaParams) (*types.MediaResponse, error)
+	NextPageStub      func(ctx context.Context, page *types.CollectionsResponse) (*types.CollectionsResponse, error)
+	PrevPageStub      func(ctx context.Context, page *types.CollectionsResponse) (*types.CollectionsResponse, error)
+	NextMediaPageStub func(ctx context.Context, page *types.MediaResponse) (*types.MediaResponse, error)
+	PrevMediaPageStub func(ctx context.Context, page *types.MediaResponse) (*types.MediaResponse, error)
+
+	all           recorder[CollectionsArgs, *types.CollectionsResponse]
+	featured      recorder[CollectionsArgs, *types.CollectionsResponse]
+	media         recorder[MediaArgs, *types.MediaResponse]
+	nextPage      pageRecorder[types.CollectionsResponse]
+	prevPage      pageRecorder[types.CollectionsResponse]
+	nextMediaPage pageRecorder[types.MediaResponse]
+	prevMediaPage pageRecorder[types.MediaResponse]
+}
+
+var _ endpoints.CollectionService = (*FakeCollectionService)(nil)
+
+// All records the call and returns the configured result.
+func (f *FakeCollectionService) All(params types.PaginationParams) (*types.CollectionsResponse, error) {
+	return f.AllWithContext(context.Background(), params)
+}
+
+// AllWithContext records the call and returns the configured result.
+func (f *FakeCollectionService) AllWithContext(ctx context.Context, params types.PaginationParams) (*types.CollectionsResponse, error) {
+	var stub func() (*types.CollectionsResponse, error)
+	if f.AllStub != nil {
+		stub = func() (*types.CollectionsResponse, error) { return f.AllStub(ctx, params) }
+	}
+	return f.all.record(CollectionsArgs{Ctx: ctx, Params: params}, stub)
+}
+
+// ListAll iterates over the pages produced by AllWithContext and NextPage.
+func (f *FakeCollectionService) ListAll(ctx context.Context, params types.PaginationParams, maxItems int) iter.Seq2[types.Collection, error] {
+	first := func(ctx context.Context) (*types.CollectionsResponse, error) { return f.AllWithContext(ctx, params) }
+	return endpoints.Paginate(ctx, maxItems, first, f.NextPage, collectionsOf)
+}
+
+// AllReturns makes All return the given result when no stub is set.
+func (f *FakeCollectionService) AllReturns(response *types.CollectionsResponse, err error) {
+	f.all.returns(response, err)
+}
+
+// AllCallCount returns the number of All calls.
+func (f *FakeCollectionService) AllCallCount() int { return f.all.count() }
+
+// AllArgsForCall returns the arguments of the i-th All call.
+func (f *FakeCollectionService) AllArgsForCall(i int) CollectionsArgs { return f.all.args(i) }
+
+// Featured records the call and returns the configured result.
+func (f *FakeCollectionService) Featured(params types.PaginationParams) (*types.CollectionsResponse, error) {
+	return f.FeaturedWithContext(context.Background(), params)
+}
+
+// FeaturedWithContext records the call and returns the configured result.
+func (f *FakeCollectionService) FeaturedWithContext(ctx context.Context, params types.PaginationParams) (*types.CollectionsResponse, error) {
+	var stub func() (*types.CollectionsResponse, error)
+	if f.FeaturedStub != nil {
+		stub = func() (*types.CollectionsResponse, error) { return f.FeaturedStub(ctx, params) }
+	}
+	return f.featured.record(CollectionsArgs{Ctx: ctx, Params: params}, stub)
+}
+
+// FeaturedAll iterates over the pages produced by FeaturedWithContext and NextPage.
+func (f *FakeCollectionService) FeaturedAll(ctx context.Context, params types.PaginationParams, maxItems int) iter.Seq2[types.Collection, error] {
+	first := func(ctx context.Context) (*types.CollectionsResponse, error) {
+		return f.FeaturedWithContext(ctx, params)
+	}
+	return endpoints.Paginate(ctx, maxItems, first, f.NextPage, collectionsOf)
+}
+
+// FeaturedReturns makes Featured return the given result when no stub is set.
+func (f *FakeCollectionService) FeaturedReturns(response *types.CollectionsResponse, err error) {
+	f.featured.returns(response, err)
+}
+
+// FeaturedCallCount returns the number of Featured calls.
+func (f *FakeCollectionService) FeaturedCallCount() int { return f.featured.count() }
+
+// FeaturedArgsForCall returns the arguments of the i-th Featured call.
+func (f *FakeCollectionService) FeaturedArgsForCall(i int) CollectionsArgs { return f.featured.args(i) }
+
+// Media records the call and returns the configured result.
+func (f *FakeCollectionService) Media(params types.MediaParams) (*types.MediaResponse, error) {
+	return f.MediaWithContext(context.Background(), params)
+}
+
+// MediaWithContext records the call and returns the configured result.
+func (f *FakeCollectionService) MediaWithContext(ctx context.Context, params types.MediaParams) (*types.MediaResponse, error) {
+	var stub func() (*types.MediaResponse, error)
+	if f.MediaStub != nil {
+		stub = func() (*types.MediaResponse, error) { return f.MediaStub(ctx, params) }
+	}
+	return f.media.record(MediaArgs{Ctx: ctx, Params: params}, stub)
+}
+
+// MediaAll iterates over the pages produced by MediaWithContext and NextMediaPage.
+func (f *FakeCollectionService) MediaAll(ctx context.Context, params types.MediaParams, maxItems int) iter.Seq2[types.Media, error] {
+	first := func(ctx context.Context) (*types.MediaResponse, error) { return f.MediaWithContext(ctx, params) }
+	return endpoints.Paginate(ctx, maxItems, first, f.NextMediaPage, mediaOf)
+}
+
+// MediaPhotos calls MediaWithContext with the photos media type and returns the photos of the result.
+func (f *FakeCollectionService) MediaPhotos(ctx context.Context, params types.MediaParams) ([]*types.Photo, error) {
+	params.MediaType = types.CollectionMediaPhotos
+	response, err := f.MediaWithContext(ctx, params)
+	if err != nil || response == nil {
+		return nil, err
+	}
+	return response.Photos(), nil
+}
+
+// MediaVideos calls MediaWithContext with the videos media type and returns the videos of the result.
+func (f *FakeCollectionService) MediaVideos(ctx context.Context, params types.MediaParams) ([]*types.Video, error) {
+	params.MediaType = types.CollectionMediaVideos
+	response, err := f.MediaWithContext(ctx, params)
+	if err != nil || response == nil {
+		return nil, err
+	}
+	return response.Videos(), nil
+}
+
+// MediaReturns makes Media return the given result when no stub is set.
+func (f *FakeCollectionService) MediaReturns(response *types.MediaResponse, err error) {
+	f.media.returns(response, err)
+}
+
+// MediaCallCount returns the number of Media calls.
+func (f *FakeCollectionService) MediaCallCount() int { return f.media.count() }
+
+// MediaArgsForCall returns the arguments of the i-th Media call.
+func (f *FakeCollectionService) MediaArgsForCall(i int) MediaArgs { return f.media.args(i) }
+
+// NextPage records the call and returns the configured result, or ErrNoMorePages.
+func (f *FakeCollectionService) NextPage(ctx context.Context, page *types.CollectionsResponse) (*types.CollectionsResponse, error) {
+	var stub func() (*types.CollectionsResponse, error)
+	if f.NextPageStub != nil {
+		stub = func() (*types.CollectionsResponse, error) { return f.NextPageStub(ctx, page) }
+	}
+	return f.nextPage.record(PageArgs[types.CollectionsResponse]{Ctx: ctx, Page: page}, stub)
+}
+
+// NextPageReturns makes NextPage return the given result when no stub is set.
+func (f *FakeCollectionService) NextPageReturns(response *types.CollectionsResponse, err error) {
+	f.nextPage.returns(response, err)
+}
+
+// NextPageCallCount returns the number of NextPage calls.
+func (f *FakeCollectionService) NextPageCallCount() int { return f.nextPage.count() }
+
+// NextPageArgsForCall returns the arguments of the i-th NextPage call.
+func (f *FakeCollectionService) NextPageArgsForCall(i int) PageArgs[types.CollectionsResponse] {
+	return f.nextPage.args(i)
+}
+
+// PrevPage records the call and returns the configured result, or ErrNoMorePages.
+func (f *FakeCollectionService) PrevPage(ctx context.Context, page *types.CollectionsResponse) (*types.CollectionsResponse, error) {
+	var stub func() (*types.CollectionsResponse, error)
+	if f.PrevPageStub != nil {
+		stub = func() (*types.CollectionsResponse, error) { return f.PrevPageStub(ctx, page) }
+	}
+	return f.prevPage.record(PageArgs[types.CollectionsResponse]{Ctx: ctx, Page: page}, stub)
+}
+
+// PrevPageReturns makes PrevPage return the given result when no stub is set.
+func (f *FakeCollectionService) PrevPageReturns(response *types.CollectionsResponse, err error) {
+	f.prevPage.returns(response, err)
+}
+
+// PrevPageCallCount returns the number of PrevPage calls.
+func (f *FakeCollectionService) PrevPageCallCount() int { return f.prevPage.count() }
+
+// PrevPageArgsForCall returns the arguments of the i-th PrevPage call.
+func (f *FakeCollectionService) PrevPageArgsForCall(i int) PageArgs[types.CollectionsResponse] {
+	return f.prevPage.args(i)
+}
+
+// NextMediaPage records the call and returns the configured result, or ErrNoMorePages.
+func (f *FakeCollectionService) NextMediaPage(ctx context.Context, page *types.MediaResponse) (*types.MediaResponse, error) {
+	var stub func() (*types.MediaResponse, error)
+	if f.NextMediaPageStub != nil {
+		stub = func() (*types.MediaResponse, error) { return f.NextMediaPageStub(ctx, page) }
+	}
+	return f.nextMediaPage.record(PageArgs[types.MediaResponse]{Ctx: ctx, Page: page}, stub)
+}
+
+// NextMediaPageReturns makes NextMediaPage return the given result when no stub is set.
+func (f *FakeCollectionService) NextMediaPageReturns(response *types.MediaResponse, err error) {
+	f.nextMediaPage.returns(response, err)
+}
+
+// NextMediaPageCallCount returns the number of NextMediaPage calls.
+func (f *FakeCollectionService) NextMediaPageCallCount() int { return f.nextMediaPage.count() }
+
+// NextMediaPageArgsForCall returns the arguments of the i-th NextMediaPage call.
+func (f *FakeCollectionService) NextMediaPageArgsForCall(i int) PageArgs[types.MediaResponse] {
+	return f.nextMediaPage.args(i)
+}
+
+// PrevMediaPage records the call and returns the configured result, or ErrNoMorePages.
+func (f *FakeCollectionService) PrevMediaPage(ctx context.Context, page *types.MediaResponse) (*types.MediaResponse, error) {
+	var stub func() (*types.MediaResponse, error)
+	if f.PrevMediaPageStub != nil {
+		stub = func() (*types.MediaResponse, error) { return f.PrevMediaPageStub(ctx, page) }
+	}
+	return f.prevMediaPage.record(PageArgs[types.MediaResponse]{Ctx: ctx, Page: page}, stub)
+}
+
+// PrevMediaPageReturns makes PrevMediaPage return the given result when no stub is set.
+func (f *FakeCollectionService) PrevMediaPageReturns(response *types.MediaResponse, err error) {
+	f.prevMediaPage.returns(response, err)
+}
+
+// PrevMediaPageCallCount returns the number of PrevMediaPage calls.
+func (f *FakeCollectionService) PrevMediaPageCallCount() int { return f.prevMediaPage.count() }
+
+// PrevMediaPageArgsForCall returns the arguments of the i-th PrevMediaPage call.
+func (f *FakeCollectionService) PrevMediaPageArgsForCall(i int) PageArgs[types.MediaResponse] {
+	return f.prevMediaPage.args(i)
+}
+
+// collectionsOf extracts the collections of a page.
+func collectionsOf(page *types.CollectionsResponse) []types.Collection { return page.Collections }
+
+// mediaOf extracts the media items of a page.
+func mediaOf(page *types.MediaResponse) []types.Media { return page.Media }
@@ -0,0 +1,111 @@
+// Package fakes provides in-memory implementations of the client service interfaces
+// for use in unit tests of code that depends on the Pexels client.
+//
+// Each operation of a fake can be configured in one of two ways:
+//
+//	fake := &fakes.FakePhotoService{}
+//	fake.GetPhotoReturns(&types.Photo{ID: 1}, nil)
+//	fake.SearchStub = func(ctx context.Context, params *types.PhotoSearchParams) (*types.PhotosResponse, error) {
+//		return &types.PhotosResponse{Photos: photos}, nil
+//	}
+//
+// Every call is recorded and can be inspected with the CallCount and ArgsForCall methods.
+// The context-free variant of an operation is recorded as a call with context.Background().
+// Unconfigured operations return zero values, except NextPage and friends which return
+// endpoints.ErrNoMorePages so that iterators terminate.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kumarsgoyal/pexels-go/client/endpoints"
+)
+
+// recorder records the arguments of an operation and produces its results.
+type recorder[A any, R any] struct {
+	mu     sync.Mutex
+	calls  []A
+	result R
+	err    error
+}
+
+// record stores the call arguments and returns the result of the stub, if any,
+// or the canned result configured with returns.
+func (r *recorder[A, R]) record(args A, stub func() (R, error)) (R, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, args)
+	result, err := r.result, r.err
+	r.mu.Unlock()
+
+	if stub != nil {
+		return stub()
+	}
+	return result, err
+}
+
+// returns sets the canned result returned when no stub is configured.
+func (r *recorder[A, R]) returns(result R, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result, r.err = result, err
+}
+
+// count returns the number of recorded calls.
+func (r *recorder[A, R]) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.calls)
+}
+
+// args returns the arguments of the i-th recorded call.
+func (r *recorder[A, R]) args(i int) A {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls[i]
+}
+
+// pageRecorder is a recorder for NextPage-style operations, which default to
+// endpoints.ErrNoMorePages when neither a stub nor a result is configured.
+type pageRecorder[R any] struct {
+	recorder[PageArgs[R], *R]
+	configured bool
+}
+
+// record behaves like recorder.record but reports ErrNoMorePages by default.
+func (r *pageRecorder[R]) record(args PageArgs[R], stub func() (*R, error)) (*R, error) {
+	r.mu.Lock()
+	configured := r.configured
+	r.mu.Unlock()
+
+	result, err := r.recorder.record(args, stub)
+	if stub == nil && !configured {
+		return nil, endpoints.ErrNoMorePages
+	}
+	return result, err
+}
+
+// returns sets the canned result and disables the ErrNoMorePages default.
+func (r *pageRecorder[R]) returns(result *R, err error) {
+	r.mu.Lock()
+	r.configured = true
+	r.mu.Unlock()
+	r.recorder.returns(result, err)
+}
+
+// PageArgs holds the arguments of a NextPage-style call.
+type PageArgs[R any] struct {
+	Ctx  context.Context
+	Page *R
+}
+
+// getMany mirrors the batch lookups of the real endpoints, but looks the IDs up
+// one at a time and in order.
+func getMany[T any](ctx context.Context, ids []int, get func(context.Context, int) (*T, error)) (*endpoints.BatchResult[T], error) {
+	batch := &endpoints.BatchResult[T]{}
+	for _, id := range ids {
+		item, err := get(ctx, id)
+		batch.Results = append(batch.Results, endpoints.ItemResult[T]{ID: id, Item: item, Err: err})
+	}
+	return batch, batch.Err()
+}
@@ -0,0 +1,170 @@
+package fakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kumarsgoyal/pexels-go/client"
+	"github.com/kumarsgoyal/pexels-go/client/endpoints"
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// The fakes stand in for every service of a PexelsClient
+var (
+	_ endpoints.PhotoService      = (*FakePhotoService)(nil)
+	_ endpoints.VideoService      = (*FakeVideoService)(nil)
+	_ endpoints.CollectionService = (*FakeCollectionService)(nil)
+)
+
+// Test that a fake can stand in for the real service on a PexelsClient
+func TestFakePhotoService(t *testing.T) {
+	fake := &FakePhotoService{}
+	fake.GetPhotoReturns(nil, client.ErrNotFound)
+	fake.SearchStub = func(ctx context.Context, params *types.PhotoSearchParams) (*types.PhotosResponse, error) {
+		return &types.PhotosResponse{Photos: []types.Photo{{ID: 1}, {ID: 2}}}, nil
+	}
+	pexelsClient := &client.PexelsClient{Photos: fake}
+
+	if _, err := pexelsClient.Photos.GetPhoto(42); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("Expected the configured error, got %v", err)
+	}
+	if fake.GetPhotoCallCount() != 1 || fake.GetPhotoArgsForCall(0).PhotoID != 42 {
+		t.Fatalf("GetPhoto call was not recorded")
+	}
+
+	// Batch lookups go through the GetPhoto configuration
+	batch, err := pexelsClient.Photos.GetMany(context.Background(), []int{7, 8}, nil)
+	if !errors.Is(err, client.ErrNotFound) || len(batch.NotFound()) != 2 || fake.GetPhotoCallCount() != 3 {
+		t.Fatalf("Unexpected batch result %+v, %v", batch, err)
+	}
+
+	// Iterators walk the stubbed pages and stop when NextPage has nothing more
+	var ids []int
+	for photo, err := range pexelsClient.Photos.SearchAll(context.Background(), &types.PhotoSearchParams{Query: "cat"}, 0) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ids = append(ids, photo.ID)
+	}
+	if len(ids) != 2 || fake.SearchCallCount() != 1 || fake.NextPageCallCount() != 1 {
+		t.Fatalf("Unexpected iteration: ids %v, %d searches, %d next pages", ids, fake.SearchCallCount(), fake.NextPageCallCount())
+	}
+	if fake.SearchArgsForCall(0).Params.Query != "cat" {
+		t.Fatalf("Search arguments were not recorded")
+	}
+}
+
+// Test that the video fake records its calls and walks canned pages
+func TestFakeVideoService(t *testing.T) {
+	fake := &FakeVideoService{}
+	first := &types.VideosResponse{Page: 1, Videos: []types.Video{{ID: 1}, {ID: 2}}}
+	second := &types.VideosResponse{Page: 2, Videos: []types.Video{{ID: 3}}}
+	fake.PopularReturns(first, nil)
+	fake.NextPageStub = func(ctx context.Context, page *types.VideosResponse) (*types.VideosResponse, error) {
+		if page == first {
+			return second, nil
+		}
+		return nil, endpoints.ErrNoMorePages
+	}
+	fake.GetVideoReturns(&types.Video{ID: 9}, nil)
+	pexelsClient := &client.PexelsClient{Videos: fake}
+
+	var ids []int
+	for video, err := range pexelsClient.Videos.PopularAll(context.Background(), &types.VideoFilterParams{MinDuration: 10}, 0) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ids = append(ids, video.ID)
+	}
+	if len(ids) != 3 || ids[2] != 3 || fake.PopularCallCount() != 1 || fake.NextPageCallCount() != 2 {
+		t.Fatalf("Unexpected iteration: ids %v, %d popular calls, %d next pages", ids, fake.PopularCallCount(), fake.NextPageCallCount())
+	}
+	if fake.PopularArgsForCall(0).Params.MinDuration != 10 || fake.NextPageArgsForCall(1).Page != second {
+		t.Fatal("Popular and NextPage arguments were not recorded")
+	}
+
+	// maxItems stops before the second page is requested
+	for range pexelsClient.Videos.PopularAll(context.Background(), nil, 2) {
+	}
+	if fake.NextPageCallCount() != 2 {
+		t.Fatalf("Expected no further NextPage call, got %d in total", fake.NextPageCallCount())
+	}
+
+	video, err := pexelsClient.Videos.GetVideo(9)
+	if err != nil || video.ID != 9 || fake.GetVideoArgsForCall(0).VideoID != 9 {
+		t.Fatalf("Unexpected video %+v, %v", video, err)
+	}
+
+	// Unconfigured operations return zero values, and PrevPage reports the first page
+	if response, err := pexelsClient.Videos.Search(&types.VideoSearchParams{Query: "ocean"}); response != nil || err != nil {
+		t.Fatalf("Expected zero values, got %+v, %v", response, err)
+	}
+	if _, err := pexelsClient.Videos.PrevPage(context.Background(), first); !errors.Is(err, endpoints.ErrNoMorePages) {
+		t.Fatalf("Expected ErrNoMorePages, got %v", err)
+	}
+	if fake.SearchCallCount() != 1 || fake.SearchArgsForCall(0).Params.Query != "ocean" || fake.PrevPageCallCount() != 1 {
+		t.Fatal("Search and PrevPage calls were not recorded")
+	}
+}
+
+// Test that the collection fake records its calls, walks canned pages and filters media
+func TestFakeCollectionService(t *testing.T) {
+	fake := &FakeCollectionService{}
+	fake.FeaturedReturns(&types.CollectionsResponse{Collections: []types.Collection{{ID: "a"}, {ID: "b"}}}, nil)
+	fake.MediaStub = func(ctx context.Context, params types.MediaParams) (*types.MediaResponse, error) {
+		return &types.MediaResponse{ID: params.CollectionID, Media: types.MediaList{&types.Photo{ID: 1}, &types.Video{ID: 2}}}, nil
+	}
+	fake.AllReturns(nil, client.ErrUnauthorized)
+	pexelsClient := &client.PexelsClient{Collections: fake}
+	ctx := context.Background()
+
+	var ids []string
+	for collection, err := range pexelsClient.Collections.FeaturedAll(ctx, types.PaginationParams{PerPage: 2}, 0) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ids = append(ids, collection.ID)
+	}
+	if len(ids) != 2 || fake.FeaturedArgsForCall(0).Params.PerPage != 2 || fake.NextPageCallCount() != 1 {
+		t.Fatalf("Unexpected iteration: ids %v, %d next pages", ids, fake.NextPageCallCount())
+	}
+
+	// Errors configured with Returns are yielded by the iterators
+	for _, err := range pexelsClient.Collections.ListAll(ctx, types.PaginationParams{}, 0) {
+		if !errors.Is(err, client.ErrUnauthorized) {
+			t.Fatalf("Expected the configured error, got %v", err)
+		}
+	}
+	if fake.AllCallCount() != 1 {
+		t.Fatalf("Expected one All call, got %d", fake.AllCallCount())
+	}
+
+	photos, err := pexelsClient.Collections.MediaPhotos(ctx, types.MediaParams{CollectionID: "a"})
+	if err != nil || len(photos) != 1 || photos[0].ID != 1 {
+		t.Fatalf("Unexpected photos %v, %v", photos, err)
+	}
+	videos, err := pexelsClient.Collections.MediaVideos(ctx, types.MediaParams{CollectionID: "a"})
+	if err != nil || len(videos) != 1 || videos[0].ID != 2 {
+		t.Fatalf("Unexpected videos %v, %v", videos, err)
+	}
+	if fake.MediaCallCount() != 2 || fake.MediaArgsForCall(0).Params.MediaType != types.CollectionMediaPhotos || fake.MediaArgsForCall(1).Params.MediaType != types.CollectionMediaVideos {
+		t.Fatal("Media calls were not recorded with their media type")
+	}
+
+	count := 0
+	for _, err := range pexelsClient.Collections.MediaAll(ctx, types.MediaParams{CollectionID: "a"}, 0) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		count++
+	}
+	if count != 2 || fake.NextMediaPageCallCount() != 1 {
+		t.Fatalf("Expected 2 media and one NextMediaPage call, got %d and %d", count, fake.NextMediaPageCallCount())
+	}
+
+	// An unconfigured operation returns no page, which ends the iteration
+	for collection, err := range (&FakeCollectionService{}).FeaturedAll(ctx, types.PaginationParams{}, 0) {
+		t.Fatalf("Expected no items, got %+v, %v", collection, err)
+	}
+}
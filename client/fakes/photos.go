@@ -0,0 +1,185 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+
+	"github.com/kumarsgoyal/pexels-go/client/endpoints"
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// PhotoSearchArgs holds the arguments of a photo search call.
+type PhotoSearchArgs struct {
+	Ctx    context.Context
+	Params *types.PhotoSearchParams
+}
+
+// CuratedArgs holds the arguments of a curated photos call.
+type CuratedArgs struct {
+	Ctx    context.Context
+	Params *types.PaginationParams
+}
+
+// GetPhotoArgs holds the arguments of a photo lookup.
+type GetPhotoArgs struct {
+	Ctx     context.Context
+	PhotoID int
+}
+
+// FakePhotoService is an in-memory endpoints.PhotoService that records its calls.
+type FakePhotoService struct {
+	SearchStub   func(ctx context.Context, params *types.PhotoSearchParams) (*types.PhotosResponse, error)
+	CuratedStub  func(ctx context.Context, params *types.PaginationParams) (*types.PhotosResponse, error)
+	GetPhotoStub func(ctx context.Context, photoID int) (*types.Photo, error)
+	NextPageStub func(ctx context.Context, page *types.PhotosResponse) (*types.PhotosResponse, error)
+	PrevPageStub func(ctx context.Context, page *types.PhotosResponse) (*types.PhotosResponse, error)
+
+	search   recorder[PhotoSearchArgs, *types.PhotosResponse]
+	curated  recorder[CuratedArgs, *types.PhotosResponse]
+	getPhoto recorder[GetPhotoArgs, *types.Photo]
+	nextPage pageRecorder[types.PhotosResponse]
+	prevPage pageRecorder[types.PhotosResponse]
+}
+
+var _ endpoints.PhotoService = (*FakePhotoService)(nil)
+
+// Search records the call and returns the configured result.
+func (f *FakePhotoService) Search(params *types.PhotoSearchParams) (*types.PhotosResponse, error) {
+	return f.SearchWithContext(context.Background(), params)
+}
+
+// SearchWithContext records the call and returns the configured result.
+func (f *FakePhotoService) SearchWithContext(ctx context.Context, params *types.PhotoSearchParams) (*types.PhotosResponse, error) {
+	var stub func() (*types.PhotosResponse, error)
+	if f.SearchStub != nil {
+		stub = func() (*types.PhotosResponse, error) { return f.SearchStub(ctx, params) }
+	}
+	return f.search.record(PhotoSearchArgs{Ctx: ctx, Params: params}, stub)
+}
+
+// SearchAll iterates over the pages produced by SearchWithContext and NextPage.
+func (f *FakePhotoService) SearchAll(ctx context.Context, params *types.PhotoSearchParams, maxItems int) iter.Seq2[types.Photo, error] {
+	first := func(ctx context.Context) (*types.PhotosResponse, error) { return f.SearchWithContext(ctx, params) }
+	return endpoints.Paginate(ctx, maxItems, first, f.NextPage, photosOf)
+}
+
+// SearchReturns makes Search return the given result when no stub is set.
+func (f *FakePhotoService) SearchReturns(response *types.PhotosResponse, err error) {
+	f.search.returns(response, err)
+}
+
+// SearchCallCount returns the number of Search calls.
+func (f *FakePhotoService) SearchCallCount() int { return f.search.count() }
+
+// SearchArgsForCall returns the arguments of the i-th Search call.
+func (f *FakePhotoService) SearchArgsForCall(i int) PhotoSearchArgs { return f.search.args(i) }
+
+// Curated records the call and returns the configured result.
+func (f *FakePhotoService) Curated(params *types.PaginationParams) (*types.PhotosResponse, error) {
+	return f.CuratedWithContext(context.Background(), params)
+}
+
+// CuratedWithContext records the call and returns the configured result.
+func (f *FakePhotoService) CuratedWithContext(ctx context.Context, params *types.PaginationParams) (*types.PhotosResponse, error) {
+	var stub func() (*types.PhotosResponse, error)
+	if f.CuratedStub != nil {
+		stub = func() (*types.PhotosResponse, error) { return f.CuratedStub(ctx, params) }
+	}
+	return f.curated.record(CuratedArgs{Ctx: ctx, Params: params}, stub)
+}
+
+// CuratedAll iterates over the pages produced by CuratedWithContext and NextPage.
+func (f *FakePhotoService) CuratedAll(ctx context.Context, params *types.PaginationParams, maxItems int) iter.Seq2[types.Photo, error] {
+	first := func(ctx context.Context) (*types.PhotosResponse, error) { return f.CuratedWithContext(ctx, params) }
+	return endpoints.Paginate(ctx, maxItems, first, f.NextPage, photosOf)
+}
+
+// CuratedReturns makes Curated return the given result when no stub is set.
+func (f *FakePhotoService) CuratedReturns(response *types.PhotosResponse, err error) {
+	f.curated.returns(response, err)
+}
+
+// CuratedCallCount returns the number of Curated calls.
+func (f *FakePhotoService) CuratedCallCount() int { return f.curated.count() }
+
+// CuratedArgsForCall returns the arguments of the i-th Curated call.
+func (f *FakePhotoService) CuratedArgsForCall(i int) CuratedArgs { return f.curated.args(i) }
+
+// GetPhoto records the call and returns the configured result.
+func (f *FakePhotoService) GetPhoto(photoID int) (*types.Photo, error) {
+	return f.GetPhotoWithContext(context.Background(), photoID)
+}
+
+// GetPhotoWithContext records the call and returns the configured result.
+func (f *FakePhotoService) GetPhotoWithContext(ctx context.Context, photoID int) (*types.Photo, error) {
+	var stub func() (*types.Photo, error)
+	if f.GetPhotoStub != nil {
+		stub = func() (*types.Photo, error) { return f.GetPhotoStub(ctx, photoID) }
+	}
+	return f.getPhoto.record(GetPhotoArgs{Ctx: ctx, PhotoID: photoID}, stub)
+}
+
+// GetMany looks up each ID in order through GetPhotoWithContext, so that
+// GetPhotoStub or GetPhotoReturns configure the per-ID results. It ignores opts and
+// makes one lookup at a time, so stubs need not be safe for concurrent use.
+func (f *FakePhotoService) GetMany(ctx context.Context, ids []int, opts *endpoints.BatchOptions) (*endpoints.BatchResult[types.Photo], error) {
+	return getMany(ctx, ids, f.GetPhotoWithContext)
+}
+
+// GetPhotoReturns makes GetPhoto return the given result when no stub is set.
+func (f *FakePhotoService) GetPhotoReturns(photo *types.Photo, err error) {
+	f.getPhoto.returns(photo, err)
+}
+
+// GetPhotoCallCount returns the number of GetPhoto calls.
+func (f *FakePhotoService) GetPhotoCallCount() int { return f.getPhoto.count() }
+
+// GetPhotoArgsForCall returns the arguments of the i-th GetPhoto call.
+func (f *FakePhotoService) GetPhotoArgsForCall(i int) GetPhotoArgs { return f.getPhoto.args(i) }
+
+// NextPage records the call and returns the configured result, or ErrNoMorePages.
+func (f *FakePhotoService) NextPage(ctx context.Context, page *types.PhotosResponse) (*types.PhotosResponse, error) {
+	var stub func() (*types.PhotosResponse, error)
+	if f.NextPageStub != nil {
+		stub = func() (*types.PhotosResponse, error) { return f.NextPageStub(ctx, page) }
+	}
+	return f.nextPage.record(PageArgs[types.PhotosResponse]{Ctx: ctx, Page: page}, stub)
+}
+
+// NextPageReturns makes NextPage return the given result when no stub is set.
+func (f *FakePhotoService) NextPageReturns(response *types.PhotosResponse, err error) {
+	f.nextPage.returns(response, err)
+}
+
+// NextPageCallCount returns the number of NextPage calls.
+func (f *FakePhotoService) NextPageCallCount() int { return f.nextPage.count() }
+
+// NextPageArgsForCall returns the arguments of the i-th NextPage call.
+func (f *FakePhotoService) NextPageArgsForCall(i int) PageArgs[types.PhotosResponse] {
+	return f.nextPage.args(i)
+}
+
+// PrevPage records the call and returns the configured result, or ErrNoMorePages.
+func (f *FakePhotoService) PrevPage(ctx context.Context, page *types.PhotosResponse) (*types.PhotosResponse, error) {
+	var stub func() (*types.PhotosResponse, error)
+	if f.PrevPageStub != nil {
+		stub = func() (*types.PhotosResponse, error) { return f.PrevPageStub(ctx, page) }
+	}
+	return f.prevPage.record(PageArgs[types.PhotosResponse]{Ctx: ctx, Page: page}, stub)
+}
+
+// PrevPageReturns makes PrevPage return the given result when no stub is set.
+func (f *FakePhotoService) PrevPageReturns(response *types.PhotosResponse, err error) {
+	f.prevPage.returns(response, err)
+}
+
+// PrevPageCallCount returns the number of PrevPage calls.
+func (f *FakePhotoService) PrevPageCallCount() int { return f.prevPage.count() }
+
+// PrevPageArgsForCall returns the arguments of the i-th PrevPage call.
+func (f *FakePhotoService) PrevPageArgsForCall(i int) PageArgs[types.PhotosResponse] {
+	return f.prevPage.args(i)
+}
+
+// photosOf extracts the photos of a page.
+func photosOf(page *types.PhotosResponse) []types.Photo { return page.Photos }
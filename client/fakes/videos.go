@@ -0,0 +1,185 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+
+	"github.com/kumarsgoyal/pexels-go/client/endpoints"
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// VideoSearchArgs holds the arguments of a video search call.
+type VideoSearchArgs struct {
+	Ctx    context.Context
+	Params *types.VideoSearchParams
+}
+
+// PopularArgs holds the arguments of a popular videos call.
+type PopularArgs struct {
+	Ctx    context.Context
+	Params *types.VideoFilterParams
+}
+
+// GetVideoArgs holds the arguments of a video lookup.
+type GetVideoArgs struct {
+	Ctx     context.Context
+	VideoID int
+}
+
+// FakeVideoService is an in-memory endpoints.VideoService that records its calls.
+type FakeVideoService struct {
+	SearchStub   func(ctx context.Context, params *types.VideoSearchParams) (*types.VideosResponse, error)
+	PopularStub  func(ctx context.Context, params *types.VideoFilterParams) (*types.VideosResponse, error)
+	GetVideoStub func(ctx context.Context, videoID int) (*types.Video, error)
+	NextPageStub func(ctx context.Context, page *types.VideosResponse) (*types.VideosResponse, error)
+	PrevPageStub func(ctx context.Context, page *types.VideosResponse) (*types.VideosResponse, error)
+
+	search   recorder[VideoSearchArgs, *types.VideosResponse]
+	popular  recorder[PopularArgs, *types.VideosResponse]
+	getVideo recorder[GetVideoArgs, *types.Video]
+	nextPage pageRecorder[types.VideosResponse]
+	prevPage pageRecorder[types.VideosResponse]
+}
+
+var _ endpoints.VideoService = (*FakeVideoService)(nil)
+
+// Search records the call and returns the configured result.
+func (f *FakeVideoService) Search(params *types.VideoSearchParams) (*types.VideosResponse, error) {
+	return f.SearchWithContext(context.Background(), params)
+}
+
+// SearchWithContext records the call and returns the configured result.
+func (f *FakeVideoService) SearchWithContext(ctx context.Context, params *types.VideoSearchParams) (*types.VideosResponse, error) {
+	var stub func() (*types.VideosResponse, error)
+	if f.SearchStub != nil {
+		stub = func() (*types.VideosResponse, error) { return f.SearchStub(ctx, params) }
+	}
+	return f.search.record(VideoSearchArgs{Ctx: ctx, Params: params}, stub)
+}
+
+// SearchAll iterates over the pages produced by SearchWithContext and NextPage.
+func (f *FakeVideoService) SearchAll(ctx context.Context, params *types.VideoSearchParams, maxItems int) iter.Seq2[types.Video, error] {
+	first := func(ctx context.Context) (*types.VideosResponse, error) { return f.SearchWithContext(ctx, params) }
+	return endpoints.Paginate(ctx, maxItems, first, f.NextPage, videosOf)
+}
+
+// SearchReturns makes Search return the given result when no stub is set.
+func (f *FakeVideoService) SearchReturns(response *types.VideosResponse, err error) {
+	f.search.returns(response, err)
+}
+
+// SearchCallCount returns the number of Search calls.
+func (f *FakeVideoService) SearchCallCount() int { return f.search.count() }
+
+// SearchArgsForCall returns the arguments of the i-th Search call.
+func (f *FakeVideoService) SearchArgsForCall(i int) VideoSearchArgs { return f.search.args(i) }
+
+// Popular records the call and returns the configured result.
+func (f *FakeVideoService) Popular(params *types.VideoFilterParams) (*types.VideosResponse, error) {
+	return f.PopularWithContext(context.Background(), params)
+}
+
+// PopularWithContext records the call and returns the configured result.
+func (f *FakeVideoService) PopularWithContext(ctx context.Context, params *types.VideoFilterParams) (*types.VideosResponse, error) {
+	var stub func() (*types.VideosResponse, error)
+	if f.PopularStub != nil {
+		stub = func() (*types.VideosResponse, error) { return f.PopularStub(ctx, params) }
+	}
+	return f.popular.record(PopularArgs{Ctx: ctx, Params: params}, stub)
+}
+
+// PopularAll iterates over the pages produced by PopularWithContext and NextPage.
+func (f *FakeVideoService) PopularAll(ctx context.Context, params *types.VideoFilterParams, maxItems int) iter.Seq2[types.Video, error] {
+	first := func(ctx context.Context) (*types.VideosResponse, error) { return f.PopularWithContext(ctx, params) }
+	return endpoints.Paginate(ctx, maxItems, first, f.NextPage, videosOf)
+}
+
+// PopularReturns makes Popular return the given result when no stub is set.
+func (f *FakeVideoService) PopularReturns(response *types.VideosResponse, err error) {
+	f.popular.returns(response, err)
+}
+
+// PopularCallCount returns the number of Popular calls.
+func (f *FakeVideoService) PopularCallCount() int { return f.popular.count() }
+
+// PopularArgsForCall returns the arguments of the i-th Popular call.
+func (f *FakeVideoService) PopularArgsForCall(i int) PopularArgs { return f.popular.args(i) }
+
+// GetVideo records the call and returns the configured result.
+func (f *FakeVideoService) GetVideo(videoID int) (*types.Video, error) {
+	return f.GetVideoWithContext(context.Background(), videoID)
+}
+
+// GetVideoWithContext records the call and returns the configured result.
+func (f *FakeVideoService) GetVideoWithContext(ctx context.Context, videoID int) (*types.Video, error) {
+	var stub func() (*types.Video, error)
+	if f.GetVideoStub != nil {
+		stub = func() (*types.Video, error) { return f.GetVideoStub(ctx, videoID) }
+	}
+	return f.getVideo.record(GetVideoArgs{Ctx: ctx, VideoID: videoID}, stub)
+}
+
+// GetMany looks up each ID in order through GetVideoWithContext, so that
+// GetVideoStub or GetVideoReturns configure the per-ID results. It ignores opts and
+// makes one lookup at a time, so stubs need not be safe for concurrent use.
+func (f *FakeVideoService) GetMany(ctx context.Context, ids []int, opts *endpoints.BatchOptions) (*endpoints.BatchResult[types.Video], error) {
+	return getMany(ctx, ids, f.GetVideoWithContext)
+}
+
+// GetVideoReturns makes GetVideo return the given result when no stub is set.
+func (f *FakeVideoService) GetVideoReturns(video *types.Video, err error) {
+	f.getVideo.returns(video, err)
+}
+
+// GetVideoCallCount returns the number of GetVideo calls.
+func (f *FakeVideoService) GetVideoCallCount() int { return f.getVideo.count() }
+
+// GetVideoArgsForCall returns the arguments of the i-th GetVideo call.
+func (f *FakeVideoService) GetVideoArgsForCall(i int) GetVideoArgs { return f.getVideo.args(i) }
+
+// NextPage records the call and returns the configured result, or ErrNoMorePages.
+func (f *FakeVideoService) NextPage(ctx context.Context, page *types.VideosResponse) (*types.VideosResponse, error) {
+	var stub func() (*types.VideosResponse, error)
+	if f.NextPageStub != nil {
+		stub = func() (*types.VideosResponse, error) { return f.NextPageStub(ctx, page) }
+	}
+	return f.nextPage.record(PageArgs[types.VideosResponse]{Ctx: ctx, Page: page}, stub)
+}
+
+// NextPageReturns makes NextPage return the given result when no stub is set.
+func (f *FakeVideoService) NextPageReturns(response *types.VideosResponse, err error) {
+	f.nextPage.returns(response, err)
+}
+
+// NextPageCallCount returns the number of NextPage calls.
+func (f *FakeVideoService) NextPageCallCount() int { return f.nextPage.count() }
+
+// NextPageArgsForCall returns the arguments of the i-th NextPage call.
+func (f *FakeVideoService) NextPageArgsForCall(i int) PageArgs[types.VideosResponse] {
+	return f.nextPage.args(i)
+}
+
+// PrevPage records the call and returns the configured result, or ErrNoMorePages.
+func (f *FakeVideoService) PrevPage(ctx context.Context, page *types.VideosResponse) (*types.VideosResponse, error) {
+	var stub func() (*types.VideosResponse, error)
+	if f.PrevPageStub != nil {
+		stub = func() (*types.VideosResponse, error) { return f.PrevPageStub(ctx, page) }
+	}
+	return f.prevPage.record(PageArgs[types.VideosResponse]{Ctx: ctx, Page: page}, stub)
+}
+
+// PrevPageReturns makes PrevPage return the given result when no stub is set.
+func (f *FakeVideoService) PrevPageReturns(response *types.VideosResponse, err error) {
+	f.prevPage.returns(response, err)
+}
+
+// PrevPageCallCount returns the number of PrevPage calls.
+func (f *FakeVideoService) PrevPageCallCount() int { return f.prevPage.count() }
+
+// PrevPageArgsForCall returns the arguments of the i-th PrevPage call.
+func (f *FakeVideoService) PrevPageArgsForCall(i int) PageArgs[types.VideosResponse] {
+	return f.prevPage.args(i)
+}
+
+// videosOf extracts the videos of a page.
+func videosOf(page *types.VideosResponse) []types.Video { return page.Videos }
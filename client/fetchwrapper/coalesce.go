@@ -0,0 +1,90 @@
+package fetchwrapper
+
+import (
+	"bytes"
+	"context"
+	"sync"
+)
+
+// flightGroup coalesces concurrent identical requests so that they share a
+// single round-trip. The zero value is ready to use.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall // In-flight calls by canonical URL
+}
+
+// flightCall is a request in flight and the callers waiting for it.
+type flightCall struct {
+	done     chan struct{}      // Closed once the result is set
+	cancel   context.CancelFunc // Cancels the request once every waiter has left
+	waiters  int                // Callers still waiting for the result
+	priority *sharedPriority    // Highest priority of the callers, used by the Scheduler
+
+	body []byte
+	err  error
+}
+
+// do runs fn once for all concurrent callers using the same key and returns its result.
+// fn runs detached from the cancellation of any single caller: a caller whose ctx is
+// done returns ctx.Err() right away, and fn is only cancelled when no caller is left.
+// The call is scheduled at the highest priority of its callers, raised as callers join.
+// Callers other than the one that started the call receive a copy of the body.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, bool, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, shared := g.calls[key]
+	if shared {
+		call.waiters++
+		call.priority.raise(PriorityFrom(ctx))
+	} else {
+		// Keep the values of ctx, such as the logger, but not its cancellation
+		priority := &sharedPriority{priority: PriorityFrom(ctx)}
+		callCtx, cancel := context.WithCancel(withSharedPriority(context.WithoutCancel(ctx), priority))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel, waiters: 1, priority: priority}
+		g.calls[key] = call
+		go g.run(callCtx, key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if shared && call.body != nil {
+			return bytes.Clone(call.body), true, call.err
+		}
+		return call.body, shared, call.err
+	case <-ctx.Done():
+		g.leave(key, call)
+		return nil, shared, ctx.Err()
+	}
+}
+
+// run performs the call and publishes its result to the waiters.
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall, fn func(ctx context.Context) ([]byte, error)) {
+	defer call.cancel()
+	call.body, call.err = fn(ctx)
+
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	close(call.done)
+}
+
+// leave removes a waiter whose context is done, cancelling the call when it was
+// the last one. Later callers then start a fresh call instead of joining it.
+func (g *flightGroup) leave(key string, call *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	call.cancel()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
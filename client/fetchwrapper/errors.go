@@ -0,0 +1,117 @@
+package fetchwrapper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// Sentinel errors describing broad categories of failures.
+// They can be matched against any error returned by the endpoints using errors.Is.
+var (
+	ErrUnauthorized = errors.New("pexels: unauthorized") // 401 or 403: missing or invalid API key
+	ErrNotFound     = errors.New("pexels: not found")    // 404: the requested resource does not exist
+	ErrRateLimited  = errors.New("pexels: rate limited") // 429: the request quota has been exhausted
+	ErrServer       = errors.New("pexels: server error") // 5xx: the API failed to handle the request
+	ErrCacheMiss    = errors.New("pexels: not in cache") // Offline mode: the response is not cached
+)
+
+// maxErrorBodyLen bounds how much of a non-JSON error body is kept in the error message.
+const maxErrorBodyLen = 512
+
+// APIError describes a non-OK response returned by the Pexels API.
+type APIError struct {
+	StatusCode int    // HTTP status code of the response
+	Message    string // Error message decoded from the Pexels error body, if any
+	Endpoint   string // Endpoint that was requested, relative to the base URL
+	RequestID  string // Request ID reported by the API, if any
+
+	// RetryAfter is how long the API asked the client to wait before retrying,
+	// taken from Retry-After or, for 429 responses, the rate-limit reset header.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("pexels: %s returned %d %s", e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += " (request id " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// Is reports whether the error belongs to the category described by target.
+// This allows errors.Is(err, ErrNotFound) and friends to work on wrapped API errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// CacheMissError is returned in offline mode when a request cannot be answered
+// from the cache. It matches ErrCacheMiss with errors.Is.
+type CacheMissError struct {
+	Endpoint string // Endpoint that was requested, relative to the base URL
+	URL      string // Canonical URL that was looked up
+}
+
+// Error implements the error interface.
+func (e *CacheMissError) Error() string {
+	return fmt.Sprintf("pexels: %s is not cached and the client is offline", e.Endpoint)
+}
+
+// Is reports whether target is ErrCacheMiss.
+func (e *CacheMissError) Is(target error) bool {
+	return target == ErrCacheMiss
+}
+
+// newAPIError builds an APIError from a non-OK response and its already-read body.
+// The body is decoded as a Pexels ErrorResponse when possible and used verbatim otherwise.
+func newAPIError(endpoint string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Endpoint:   endpoint,
+		RequestID:  requestID(resp.Header),
+		RetryAfter: retryAfter(resp),
+	}
+
+	var errResp types.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
+		apiErr.Message = errResp.Error
+		return apiErr
+	}
+
+	// Fall back to the raw body, trimmed to something readable
+	msg := strings.TrimSpace(string(body))
+	if len(msg) > maxErrorBodyLen {
+		msg = msg[:maxErrorBodyLen] + "..."
+	}
+	apiErr.Message = msg
+	return apiErr
+}
+
+// requestID extracts the request identifier from the response headers, if present.
+func requestID(header http.Header) string {
+	for _, key := range []string{"X-Request-Id", "Cf-Ray"} {
+		if id := header.Get(key); id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -1,28 +1,53 @@
 package fetchwrapper
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/kumarsgoyal/pexels-go/client/cache"
+	"github.com/kumarsgoyal/pexels-go/utils"
 )
 
+// DefaultUserAgent is the User-Agent sent with every request unless overridden.
+const DefaultUserAgent = "Pexels-Go/1.0"
+
+// DefaultTimeout is the timeout of the HTTP client created by NewFetchWrapper.
+const DefaultTimeout = 30 * time.Second
+
+// maxErrorBodyReadLen caps how many bytes of an error response body are read.
+const maxErrorBodyReadLen = 64 << 10
+
 // FetchWrapper struct holds the base URL, API key, and HTTP client for making requests.
 type FetchWrapper struct {
-	BaseURL string       // The base URL of the API endpoint
-	APIKey  string       // The API key for authenticating requests
-	Client  *http.Client // The HTTP client used to make requests
+	BaseURL   string        // The base URL of the API endpoint
+	APIKey    string        // The API key for authenticating requests
+	UserAgent string        // The User-Agent header sent with every request
+	Client    *http.Client  // The HTTP client used to make requests
+	Quota     *QuotaTracker // Optional: tracks rate-limit headers and enforces the quota policy
+	Retry     *RetryPolicy  // Optional: retries transient failures; nil disables retries
+	Logger    *slog.Logger  // Optional: structured logger; nil discards all log output
+	Cache     cache.Cache   // Optional: serves repeated requests without contacting the API
+	Offline   bool          // Serve only from Cache, including expired entries, and never contact the API
+	Coalesce  bool          // Share one round-trip between concurrent requests for the same URL
+	Scheduler *Scheduler    // Optional: caps and paces requests in flight, by priority
+
+	flights flightGroup // Requests in flight, used when Coalesce is set
 }
 
 // NewFetchWrapper initializes a new FetchWrapper instance with the provided base URL and API key.
 // It also sets the default timeout for HTTP requests.
 func NewFetchWrapper(baseURL, apiKey string) *FetchWrapper {
 	return &FetchWrapper{
-		BaseURL: baseURL,
-		APIKey:  apiKey,
-		Client:  &http.Client{Timeout: 30 * time.Second}, // Set a 30-second timeout for all requests
+		BaseURL:   baseURL,
+		APIKey:    apiKey,
+		UserAgent: DefaultUserAgent,
+		Client:    &http.Client{Timeout: DefaultTimeout}, // Set a 30-second timeout for all requests
 	}
 }
 
@@ -36,62 +61,232 @@ func (fw *FetchWrapper) constructQueryString(params map[string]interface{}) stri
 	return values.Encode() // Return the URL-encoded query string
 }
 
-// createRequest builds an HTTP GET request for the provided endpoint and query string.
-// It adds necessary headers, including the API key and user-agent, for authenticating the request.
-func (fw *FetchWrapper) createRequest(endpoint, queryString string) (*http.Request, error) {
-	// Construct full URL with endpoint and query string
-	fullURL := fmt.Sprintf("%s%s?%s", fw.BaseURL, endpoint, queryString)
-	log.Printf("Making request to: %s", fullURL) // Log the URL being requested
+// buildURL joins the base URL, the endpoint and the query string into a full request URL.
+func (fw *FetchWrapper) buildURL(endpoint, queryString string) string {
+	return fmt.Sprintf("%s%s?%s", fw.BaseURL, endpoint, queryString)
+}
 
+// createRequest builds an HTTP GET request for the provided full URL.
+// It adds necessary headers, including the API key and user-agent, for authenticating the request.
+// The request is bound to ctx so that cancellation and deadlines propagate to the HTTP call.
+func (fw *FetchWrapper) createRequest(ctx context.Context, fullURL string) (*http.Request, error) {
 	// Create the HTTP GET request
-	req, err := http.NewRequest("GET", fullURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
 	if err != nil {
-		log.Printf("Error creating request: %s", err)
+		fw.log().Debug("error creating request", "url", fullURL, "error", err)
 		return nil, err
 	}
 
 	// Set authorization and user-agent headers
 	req.Header.Add("Authorization", fw.APIKey)
-	req.Header.Add("User-Agent", "Pexels-Go/1.0")
+	userAgent := fw.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	req.Header.Add("User-Agent", userAgent)
 
 	return req, nil
 }
 
 // Fetch performs a GET request to the given endpoint with the specified parameters.
 // It returns the decoded JSON response or an error if the request fails.
+// It is equivalent to FetchWithContext with context.Background().
 func (fw *FetchWrapper) Fetch(endpoint string, params map[string]interface{}) ([]byte, error) {
+	return fw.FetchWithContext(context.Background(), endpoint, params)
+}
+
+// FetchWithContext performs a GET request to the given endpoint with the specified parameters.
+// The provided context controls cancellation and deadlines of the underlying HTTP call.
+// When a RetryPolicy is configured, transient failures are retried according to it.
+func (fw *FetchWrapper) FetchWithContext(ctx context.Context, endpoint string, params map[string]interface{}) ([]byte, error) {
 	// Construct query string from parameters
 	queryString := fw.constructQueryString(params)
 
+	return fw.fetch(ctx, endpoint, fw.buildURL(endpoint, queryString))
+}
+
+// FetchQuery performs a GET request to the given endpoint with already encoded query values,
+// such as those produced by query.Encode. The values are sorted by key in the request URL.
+func (fw *FetchWrapper) FetchQuery(ctx context.Context, endpoint string, values url.Values) ([]byte, error) {
+	return fw.fetch(ctx, endpoint, fw.buildURL(endpoint, values.Encode()))
+}
+
+// FetchURL performs a GET request to an absolute URL previously returned by the API,
+// such as the next_page link of a paginated response. The URL must point at the
+// same host as BaseURL so that the API key is never sent elsewhere.
+func (fw *FetchWrapper) FetchURL(ctx context.Context, rawURL string) ([]byte, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	base, err := url.Parse(fw.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %w", fw.BaseURL, err)
+	}
+	if !target.IsAbs() || target.Scheme != base.Scheme || target.Host != base.Host {
+		return nil, fmt.Errorf("refusing to fetch %q: URL is not on %s://%s", rawURL, base.Scheme, base.Host)
+	}
+
+	return fw.fetch(ctx, target.Path, target.String())
+}
+
+// fetch answers the request for fullURL from the cache when possible and
+// otherwise performs it, joining an identical request already in flight when
+// coalescing is enabled. Successful responses are stored in the cache.
+func (fw *FetchWrapper) fetch(ctx context.Context, endpoint, fullURL string) ([]byte, error) {
+	if fw.Offline {
+		return fw.fetchOffline(endpoint, fullURL)
+	}
+
+	key := cache.ScopedKey(fw.APIKey, fullURL)
+	if fw.Cache != nil {
+		if entry, ok := fw.Cache.Get(key); ok {
+			fw.log().Debug("cache hit", "endpoint", endpoint)
+			return bytes.Clone(entry.Body), nil
+		}
+	}
+
+	if !fw.Coalesce {
+		return fw.fetchAndStore(ctx, endpoint, fullURL, key)
+	}
+	body, shared, err := fw.flights.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		return fw.fetchAndStore(ctx, endpoint, fullURL, key)
+	})
+	if shared {
+		fw.log().Debug("shared in-flight request", "endpoint", endpoint)
+	}
+	return body, err
+}
+
+// fetchAndStore performs the request for fullURL and stores a successful response
+// in the cache under key. The cache keeps its own copy, so callers may modify the body.
+func (fw *FetchWrapper) fetchAndStore(ctx context.Context, endpoint, fullURL, key string) ([]byte, error) {
+	body, header, err := fw.fetchWithRetry(ctx, endpoint, fullURL)
+	if err != nil {
+		return nil, err
+	}
+	if fw.Cache != nil {
+		fw.Cache.Set(key, &cache.Entry{URL: cache.Key(fullURL), Body: bytes.Clone(body), Header: header, FetchedAt: time.Now()})
+	}
+	return body, nil
+}
+
+// fetchOffline answers the request for fullURL from the cache only, accepting
+// expired entries when the cache keeps them. It never contacts the API.
+func (fw *FetchWrapper) fetchOffline(endpoint, fullURL string) ([]byte, error) {
+	key := cache.ScopedKey(fw.APIKey, fullURL)
+	if fw.Cache != nil {
+		if entry, ok := fw.Cache.Get(key); ok {
+			return bytes.Clone(entry.Body), nil
+		}
+		if stale, ok := fw.Cache.(cache.StaleReader); ok {
+			if entry, ok := stale.GetStale(key); ok {
+				fw.log().Debug("serving stale cache entry", "endpoint", endpoint, "fetched_at", entry.FetchedAt)
+				return bytes.Clone(entry.Body), nil
+			}
+		}
+	}
+
+	fw.log().Debug("cache miss in offline mode", "endpoint", endpoint)
+	return nil, &CacheMissError{Endpoint: endpoint, URL: cache.Key(fullURL)}
+}
+
+// fetchWithRetry performs the request for fullURL, retrying transient failures
+// according to the configured RetryPolicy. The endpoint is used for logging and errors.
+func (fw *FetchWrapper) fetchWithRetry(ctx context.Context, endpoint, fullURL string) ([]byte, http.Header, error) {
+	for attempt := 1; ; attempt++ {
+		body, header, err := fw.fetchOnce(ctx, endpoint, fullURL, attempt)
+		if err == nil {
+			return body, header, nil
+		}
+
+		// Give up unless the retry policy allows another attempt
+		if !fw.Retry.shouldRetry(ctx, attempt, err) {
+			return nil, nil, err
+		}
+
+		delay := fw.Retry.delay(attempt, err)
+		fw.log().Warn("retrying request", "endpoint", endpoint, "attempt", attempt, "delay", delay, "error", err)
+		if fw.Retry.OnRetry != nil {
+			fw.Retry.OnRetry(RetryEvent{Endpoint: endpoint, Attempt: attempt, Delay: delay, Err: err})
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// fetchOnce performs a single attempt of a GET request and returns the response body and headers.
+func (fw *FetchWrapper) fetchOnce(ctx context.Context, endpoint, fullURL string, attempt int) ([]byte, http.Header, error) {
+	logger := fw.log().With("endpoint", endpoint, "attempt", attempt)
+
 	// Create the HTTP request
-	req, err := fw.createRequest(endpoint, queryString)
+	req, err := fw.createRequest(ctx, fullURL)
 	if err != nil {
-		return nil, err // Return error if request creation failed
+		return nil, nil, err // Return error if request creation failed
+	}
+
+	// Wait for the scheduler to allow another request in flight
+	if fw.Scheduler != nil {
+		release, err := fw.Scheduler.Acquire(ctx)
+		if err != nil {
+			logger.Debug("request not scheduled", "error", err)
+			return nil, nil, err
+		}
+		defer release()
+	}
+
+	// Apply the quota policy once the request is about to be sent, so that requests
+	// abandoned while queued in the scheduler do not use up the quota
+	if fw.Quota != nil {
+		if err := fw.Quota.Reserve(ctx); err != nil {
+			logger.Debug("request not sent", "error", err)
+			return nil, nil, err
+		}
 	}
 
 	// Execute the request using the HTTP client
+	logger.Debug("sending request", "url", fullURL)
+	start := time.Now()
 	resp, err := fw.Client.Do(req)
 	if err != nil {
-		log.Printf("Error making request: %s", err)
-		return nil, err // Return error if the request execution failed
+		logger.Debug("error making request", "latency", time.Since(start), "error", err)
+		return nil, nil, err // Return error if the request execution failed
 	}
 	defer resp.Body.Close() // Ensure that the response body is closed when done
 
+	// Record the latest quota state reported by the API
+	if fw.Quota != nil {
+		fw.Quota.Update(resp.Header)
+	}
+	if fw.Scheduler != nil {
+		fw.Scheduler.Update(resp.Header)
+	}
+
 	// Log the status code of the response
-	log.Printf("Received response with status: %s", resp.Status)
+	logger.Debug("received response", "status", resp.StatusCode, "latency", time.Since(start))
 
 	// Check if the response status code is OK (200)
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("Error: Received non-OK response: %d", resp.StatusCode)
-		return nil, fmt.Errorf("received non-OK response: %d", resp.StatusCode)
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyReadLen))
+		return nil, nil, newAPIError(endpoint, resp, errBody)
 	}
 
 	// Read the response body into a byte slice
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("Error reading response body: %s", err)
-		return nil, err // Return error if reading the body failed
+		logger.Debug("error reading response body", "error", err)
+		return nil, nil, err // Return error if reading the body failed
 	}
 
-	return body, nil // Return the response body
+	return body, resp.Header, nil // Return the response body and headers
+}
+
+// log returns the configured logger, or one that discards everything.
+func (fw *FetchWrapper) log() *slog.Logger {
+	if fw.Logger == nil {
+		return utils.DiscardLogger()
+	}
+	return fw.Logger
 }
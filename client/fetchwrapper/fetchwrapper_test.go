@@ -0,0 +1,644 @@
+package fetchwrapper
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kumarsgoyal/pexels-go/client/cache"
+)
+
+// Test that cancelling the context or reaching its deadline aborts a request held by the server
+func TestFetchContext(t *testing.T) {
+	arrived := make(chan struct{}, 2)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	fw := NewFetchWrapper(server.URL+"/", "test-key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-arrived
+		cancel()
+	}()
+	start := time.Now()
+	if _, err := fw.FetchWithContext(ctx, "curated", nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := fw.FetchWithContext(ctx, "curated", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Requests were not aborted, took %v", elapsed)
+	}
+}
+
+// Test that non-OK responses are surfaced as *APIError with matching categories
+func TestFetchAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Not Found"}`))
+	}))
+	defer server.Close()
+
+	fw := NewFetchWrapper(server.URL+"/", "test-key")
+	_, err := fw.FetchWithContext(context.Background(), "photos/1", nil)
+	if err == nil {
+		t.Fatal("Expected an error for a 404 response")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "Not Found" || apiErr.RequestID != "req-123" {
+		t.Fatalf("Unexpected API error: %+v", apiErr)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatal("Expected errors.Is(err, ErrNotFound) to be true")
+	}
+	if errors.Is(err, ErrServer) {
+		t.Fatal("Expected errors.Is(err, ErrServer) to be false")
+	}
+}
+
+// Test that rate-limit headers are tracked and the fail-fast policy refuses exhausted quotas
+func TestFetchQuotaFailFast(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set(HeaderRateLimitLimit, "200")
+		w.Header().Set(HeaderRateLimitRemaining, "0")
+		w.Header().Set(HeaderRateLimitReset, strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	fw := NewFetchWrapper(server.URL+"/", "test-key")
+	fw.Quota = NewQuotaTracker()
+	fw.Quota.SetPolicy(QuotaFailFast)
+
+	if _, err := fw.FetchWithContext(context.Background(), "curated", nil); err != nil {
+		t.Fatalf("First request should be sent: %v", err)
+	}
+
+	limit, ok := fw.Quota.RateLimit()
+	if !ok || limit.Limit != 200 || limit.Remaining != 0 {
+		t.Fatalf("Unexpected rate limit state: %+v (known %v)", limit, ok)
+	}
+
+	_, err := fw.FetchWithContext(context.Background(), "curated", nil)
+	if !errors.Is(err, ErrQuotaExhausted) {
+		t.Fatalf("Expected ErrQuotaExhausted, got %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("Expected exactly one request to reach the server, got %d", requests)
+	}
+}
+
+// Test that a request abandoned while queued in the scheduler does not use up the quota
+func TestFetchQuotaAfterScheduler(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	fw := NewFetchWrapper(server.URL+"/", "test-key")
+	fw.Quota = NewQuotaTracker()
+	fw.Quota.SetPolicy(QuotaFailFast)
+	fw.Quota.Update(http.Header{
+		HeaderRateLimitLimit:     {"200"},
+		HeaderRateLimitRemaining: {"1"},
+		HeaderRateLimitReset:     {strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+	})
+	fw.Scheduler = NewScheduler(SchedulerConfig{MaxInFlight: 1})
+	release, err := fw.Scheduler.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := fw.FetchWithContext(ctx, "curated", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the queued request to time out, got %v", err)
+	}
+	release()
+
+	if _, err := fw.FetchWithContext(context.Background(), "curated", nil); err != nil || requests != 1 {
+		t.Fatalf("Expected the remaining quota to be spent on the next request, got %v after %d requests", err, requests)
+	}
+}
+
+// Test that transient failures are retried, Retry-After is honored and the hook is called
+func TestFetchRetry(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"photos":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	var events []RetryEvent
+	fw := NewFetchWrapper(server.URL+"/", "test-key")
+	fw.Retry = DefaultRetryPolicy()
+	fw.Retry.BaseDelay = time.Millisecond
+	fw.Retry.OnRetry = func(event RetryEvent) { events = append(events, event) }
+
+	body, err := fw.FetchWithContext(context.Background(), "curated", nil)
+	if err != nil {
+		t.Fatalf("Expected the request to succeed after retries: %v", err)
+	}
+	if string(body) != `{"photos":[]}` {
+		t.Fatalf("Unexpected body: %s", body)
+	}
+	if requests != 3 || len(events) != 2 {
+		t.Fatalf("Expected 3 requests and 2 retry events, got %d and %d", requests, len(events))
+	}
+	if !errors.Is(events[1].Err, ErrRateLimited) {
+		t.Fatalf("Expected the second retry to be caused by a rate limit, got %v", events[1].Err)
+	}
+
+	// A non-retryable status fails immediately
+	requests = 0
+	fw.Retry.RetryableStatus = []int{http.StatusServiceUnavailable}
+	if _, err := fw.FetchWithContext(context.Background(), "curated", nil); !errors.Is(err, ErrServer) {
+		t.Fatalf("Expected a server error, got %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("Expected a single request for a non-retryable status, got %d", requests)
+	}
+}
+
+// Test that a Retry-After within MaxDelay is honored and a longer one ends the retries
+func TestFetchRetryAfterMaxDelay(t *testing.T) {
+	requests := 0
+	retryAfter := "1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	fw := NewFetchWrapper(server.URL+"/", "test-key")
+	fw.Retry = DefaultRetryPolicy()
+	fw.Retry.MaxDelay = 2 * time.Second
+	fw.Retry.OnRetry = func(event RetryEvent) { delays = append(delays, event.Delay) }
+
+	if _, err := fw.FetchWithContext(context.Background(), "curated", nil); err != nil {
+		t.Fatalf("Expected the request to succeed after waiting: %v", err)
+	}
+	if requests != 2 || len(delays) != 1 || delays[0] != time.Second {
+		t.Fatalf("Expected one retry after 1s, got %d requests and delays %v", requests, delays)
+	}
+
+	// Waiting an hour for the quota to reset exceeds MaxDelay: the error is returned at once
+	requests, delays, retryAfter = 0, nil, "3600"
+	start := time.Now()
+	_, err := fw.FetchWithContext(context.Background(), "curated", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Hour {
+		t.Fatalf("Expected an APIError asking to retry after an hour, got %v", err)
+	}
+	if requests != 1 || len(delays) != 0 || time.Since(start) > time.Second {
+		t.Fatalf("Expected no retry, got %d requests and delays %v", requests, delays)
+	}
+}
+
+// Test that delays are capped at MaxDelay, including delays requested by the API
+func TestRetryDelayCap(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	if d := policy.delay(10, errors.New("connection reset")); d != 5*time.Second {
+		t.Fatalf("Expected the backoff to be capped at 5s, got %v", d)
+	}
+	if d := policy.delay(1, &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute}); d != 5*time.Second {
+		t.Fatalf("Expected Retry-After to be capped at 5s, got %v", d)
+	}
+	if d := policy.delay(1, &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 3 * time.Second}); d != 3*time.Second {
+		t.Fatalf("Expected Retry-After to be honored, got %v", d)
+	}
+}
+
+// Test that two API keys sharing a disk cache never see each other's responses, online or offline
+func TestFetchCacheScopedByAPIKey(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"collections":[{"id":"` + r.Header.Get("Authorization") + `"}]}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	disk, err := cache.NewDisk(cache.DiskOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetch := func(apiKey string, offline bool) (string, error) {
+		fw := NewFetchWrapper(server.URL+"/v1/collections/", apiKey)
+		fw.Cache, fw.Offline = disk, offline
+		body, err := fw.FetchWithContext(context.Background(), "", nil)
+		return string(body), err
+	}
+
+	for _, apiKey := range []string{"key-a", "key-b", "key-a", "key-b"} {
+		body, err := fetch(apiKey, false)
+		if err != nil || !strings.Contains(body, apiKey) {
+			t.Fatalf("%s: unexpected response %s, %v", apiKey, body, err)
+		}
+	}
+	if requests != 2 {
+		t.Fatalf("Expected one request per API key, got %d", requests)
+	}
+
+	// A second process sharing the directory, offline
+	disk, err = cache.NewDisk(cache.DiskOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body, err := fetch("key-b", true); err != nil || !strings.Contains(body, "key-b") {
+		t.Fatalf("Unexpected offline response %s, %v", body, err)
+	}
+	if _, err := fetch("key-c", true); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Expected a cache miss for another API key, got %v", err)
+	}
+
+	// The API keys are not written to disk, only the responses that contain them
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		var entry cache.Entry
+		if err := json.Unmarshal(data, &entry); err != nil || strings.Contains(entry.Key, "key-") || strings.Contains(entry.URL, "key-") {
+			t.Fatalf("Unexpected entry %s: %s", file, data)
+		}
+	}
+}
+
+// Test that modifying a returned body does not corrupt the cached response, online or offline
+func TestFetchCacheCopiesBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	fw := NewFetchWrapper(server.URL+"/", "test-key")
+	fw.Cache = cache.NewMemory(cache.MemoryOptions{})
+	for _, offline := range []bool{false, false, true} {
+		fw.Offline = offline
+		body, err := fw.FetchWithContext(context.Background(), "photos/1", nil)
+		if err != nil || string(body) != `{"id":1}` {
+			t.Fatalf("Offline %v: unexpected response %q, %v", offline, body, err)
+		}
+		copy(body, "XXXX")
+	}
+}
+
+// waitForWaiters blocks until n callers are waiting on the in-flight request for key.
+func waitForWaiters(t *testing.T, fw *FetchWrapper, key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		fw.flights.mu.Lock()
+		call := fw.flights.calls[key]
+		waiting := call != nil && call.waiters == n
+		fw.flights.mu.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d callers", n)
+}
+
+// Test that concurrent identical requests share one round-trip and survive the first caller leaving
+func TestFetchCoalescing(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	aborted := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/photos/2" {
+			// Never answers, so only cancellation ends the request
+			<-r.Context().Done()
+			close(aborted)
+			return
+		}
+		<-release
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	fw := NewFetchWrapper(server.URL+"/", "test-key")
+	fw.Coalesce = true
+	key := cache.ScopedKey(fw.APIKey, fw.buildURL("photos/1", ""))
+
+	// The first caller starts the request and then gives up
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := fw.FetchWithContext(firstCtx, "photos/1", nil)
+		firstErr <- err
+	}()
+	waitForWaiters(t, fw, key, 1)
+
+	const callers = 4
+	var wg sync.WaitGroup
+	bodies := make([][]byte, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			bodies[i], errs[i] = fw.FetchWithContext(context.Background(), "photos/1", nil)
+		}(i)
+	}
+	waitForWaiters(t, fw, key, callers+1)
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the first caller to be cancelled, got %v", err)
+	}
+	close(release)
+	wg.Wait()
+
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil || string(bodies[i]) != `{"id":1}` {
+			t.Fatalf("Caller %d got %q, %v", i, bodies[i], errs[i])
+		}
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("Expected a single request, got %d", requests.Load())
+	}
+
+	// The shared request is cancelled once every caller has left
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := fw.FetchWithContext(ctx, "photos/2", nil)
+		done <- err
+	}()
+	waitForWaiters(t, fw, cache.ScopedKey(fw.APIKey, fw.buildURL("photos/2", "")), 1)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a cancellation error, got %v", err)
+	}
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the abandoned request to be cancelled")
+	}
+}
+
+// Test that the scheduler caps the number of requests in flight
+func TestSchedulerMaxInFlight(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	fw := NewFetchWrapper(server.URL+"/", "test-key")
+	fw.Scheduler = NewScheduler(SchedulerConfig{MaxInFlight: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := fw.FetchWithContext(context.Background(), "photos/"+strconv.Itoa(i), nil); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if peak.Load() > 2 {
+		t.Fatalf("Expected at most 2 requests in flight, got %d", peak.Load())
+	}
+}
+
+// Test that waiting requests are released by priority, then in arrival order
+func TestSchedulerPriority(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{MaxInFlight: 1})
+	release, err := s.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	wantQueued := 0
+	enqueue := func(name string, priority Priority) {
+		wantQueued++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			done, err := s.Acquire(WithPriority(context.Background(), priority))
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			done()
+		}()
+		// Wait until the request is queued so that arrival order is deterministic
+		for {
+			s.mu.Lock()
+			queued := s.queue.Len()
+			s.mu.Unlock()
+			if queued == wantQueued {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	enqueue("low", PriorityLow)
+	enqueue("normal-1", PriorityNormal)
+	enqueue("high", PriorityHigh)
+	enqueue("normal-2", PriorityNormal)
+
+	release()
+	wg.Wait()
+	if got := strings.Join(order, ","); got != "high,normal-1,normal-2,low" {
+		t.Fatalf("Unexpected order: %s", got)
+	}
+}
+
+// Test that a coalesced request waits in the scheduler at the highest priority of its callers
+func TestSchedulerCoalescing(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		order = append(order, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/blocker" {
+			close(started)
+			<-release
+		}
+		w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+
+	s := NewScheduler(SchedulerConfig{MaxInFlight: 1})
+	fw := NewFetchWrapper(server.URL+"/", "test-key")
+	fw.Scheduler, fw.Coalesce = s, true
+	waitForQueue := func(n int) {
+		for {
+			s.mu.Lock()
+			queued := s.queue.Len()
+			s.mu.Unlock()
+			if queued == n {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	var wg sync.WaitGroup
+	bodies := make([][]byte, 4)
+	errs := make([]error, 4)
+	fetch := func(i int, endpoint string, priority Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bodies[i], errs[i] = fw.FetchWithContext(WithPriority(context.Background(), priority), endpoint, nil)
+		}()
+	}
+
+	// The blocker holds the only slot while the other requests queue up
+	fetch(0, "blocker", PriorityNormal)
+	<-started
+	fetch(1, "normal", PriorityNormal)
+	waitForQueue(1)
+	fetch(2, "shared", PriorityLow)
+	waitForQueue(2)
+	key := cache.ScopedKey(fw.APIKey, fw.buildURL("shared", ""))
+	waitForWaiters(t, fw, key, 1)
+
+	// A high-priority caller joins the low-priority request and moves it ahead
+	fetch(3, "shared", PriorityHigh)
+	waitForWaiters(t, fw, key, 2)
+	close(release)
+	wg.Wait()
+
+	for i, want := range []string{"/blocker", "/normal", "/shared", "/shared"} {
+		if errs[i] != nil || string(bodies[i]) != `{"path":"`+want+`"}` {
+			t.Fatalf("Caller %d got %q, %v", i, bodies[i], errs[i])
+		}
+	}
+	if got := strings.Join(order, ","); got != "/blocker,/shared,/normal" {
+		t.Fatalf("Unexpected order: %s", got)
+	}
+	if s.inFlight != 0 {
+		t.Fatalf("Expected every slot to be released, got %d in flight", s.inFlight)
+	}
+}
+
+// Test that a waiter abandoned after being granted hands back its slot and token
+func TestSchedulerAbandon(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{MaxInFlight: 1, Rate: 0.001, Burst: 2})
+	enqueue := func() *waiter {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w := &waiter{ready: make(chan struct{})}
+		heap.Push(&s.queue, w)
+		s.dispatch()
+		return w
+	}
+
+	granted, queued := enqueue(), enqueue()
+	if !granted.granted || queued.granted || s.tokens >= 2 {
+		t.Fatalf("Expected one grant spending a token, got %+v and %+v with %.2f tokens", granted, queued, s.tokens)
+	}
+	s.abandon(queued)
+	s.abandon(granted)
+	if s.inFlight != 0 || s.queue.Len() != 0 || s.tokens < 1.99 {
+		t.Fatalf("Expected the slot and token back, got %d in flight, %d queued and %.2f tokens", s.inFlight, s.queue.Len(), s.tokens)
+	}
+}
+
+// Test token bucket pacing and the adaptive rate derived from rate-limit headers
+func TestSchedulerRate(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{Rate: 100})
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := s.Acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		release()
+	}
+	// The first token is available immediately, the other four take 10ms each
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatalf("Expected requests to be paced, took %v", elapsed)
+	}
+
+	// An exhausted quota holds requests back until it resets
+	s = NewScheduler(SchedulerConfig{Adaptive: true})
+	header := http.Header{}
+	header.Set(HeaderRateLimitLimit, "200")
+	header.Set(HeaderRateLimitRemaining, "0")
+	header.Set(HeaderRateLimitReset, strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	s.Update(header)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := s.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the request to wait for the reset, got %v", err)
+	}
+	if s.queue.Len() != 0 {
+		t.Fatal("Expected the abandoned request to leave the queue")
+	}
+}
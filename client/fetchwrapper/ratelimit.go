@@ -0,0 +1,141 @@
+package fetchwrapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rate-limit headers returned by the Pexels API on every response.
+const (
+	HeaderRateLimitLimit     = "X-Ratelimit-Limit"     // Total requests allowed in the current period
+	HeaderRateLimitRemaining = "X-Ratelimit-Remaining" // Requests left in the current period
+	HeaderRateLimitReset     = "X-Ratelimit-Reset"     // UNIX timestamp at which the period resets
+)
+
+// ErrQuotaExhausted is returned when the quota policy refuses to send a request
+// because the remaining request budget is known to be zero.
+var ErrQuotaExhausted = errors.New("pexels: request quota exhausted")
+
+// QuotaPolicy controls what happens before a request is sent once the quota is exhausted.
+type QuotaPolicy int
+
+const (
+	QuotaIgnore   QuotaPolicy = iota // Send requests regardless of the remaining quota (default)
+	QuotaFailFast                    // Return ErrQuotaExhausted without sending the request
+	QuotaWait                        // Block until the quota resets or the context is done
+)
+
+// RateLimit is a snapshot of the quota state reported by the API.
+type RateLimit struct {
+	Limit     int       // Total requests allowed in the current period
+	Remaining int       // Requests left in the current period
+	Reset     time.Time // Time at which the period resets
+}
+
+// QuotaTracker records the latest rate-limit headers and enforces a QuotaPolicy.
+// A single tracker is meant to be shared by every FetchWrapper using the same API key.
+// It is safe for concurrent use.
+type QuotaTracker struct {
+	mu     sync.Mutex
+	limit  RateLimit
+	known  bool
+	policy QuotaPolicy
+}
+
+// NewQuotaTracker creates a QuotaTracker with the QuotaIgnore policy.
+func NewQuotaTracker() *QuotaTracker {
+	return &QuotaTracker{}
+}
+
+// SetPolicy changes the policy applied before each request.
+func (q *QuotaTracker) SetPolicy(policy QuotaPolicy) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.policy = policy
+}
+
+// RateLimit returns the latest known quota state.
+// The boolean is false until a response carrying rate-limit headers has been seen.
+func (q *QuotaTracker) RateLimit() (RateLimit, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.limit, q.known
+}
+
+// Update records the rate-limit headers of a response. Responses without
+// the headers leave the current state untouched.
+func (q *QuotaTracker) Update(header http.Header) {
+	limit, ok := parseRateLimit(header)
+	if !ok {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.limit = limit
+	q.known = true
+}
+
+// Reserve is called before a request is sent. It consumes one request from the
+// known remaining budget, or applies the policy when the budget is exhausted.
+func (q *QuotaTracker) Reserve(ctx context.Context) error {
+	for {
+		q.mu.Lock()
+		if q.policy == QuotaIgnore || !q.known {
+			q.mu.Unlock()
+			return nil
+		}
+
+		// Once the reset time has passed (or was never reported) the old numbers no longer apply
+		if q.limit.Reset.IsZero() || !time.Now().Before(q.limit.Reset) {
+			q.mu.Unlock()
+			return nil
+		}
+
+		if q.limit.Remaining > 0 {
+			q.limit.Remaining--
+			q.mu.Unlock()
+			return nil
+		}
+
+		reset, policy := q.limit.Reset, q.policy
+		q.mu.Unlock()
+
+		if policy == QuotaFailFast {
+			return fmt.Errorf("%w: resets at %s", ErrQuotaExhausted, reset.Format(time.RFC3339))
+		}
+
+		// QuotaWait: sleep until the reset time and re-check
+		timer := time.NewTimer(time.Until(reset))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// parseRateLimit extracts the rate-limit headers. It reports false when the
+// limit or remaining header is missing or malformed.
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get(HeaderRateLimitLimit))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get(HeaderRateLimitRemaining))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	rl := RateLimit{Limit: limit, Remaining: remaining}
+	if reset, err := strconv.ParseInt(header.Get(HeaderRateLimitReset), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	return rl, true
+}
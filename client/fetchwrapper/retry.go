@@ -0,0 +1,185 @@
+package fetchwrapper
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how FetchWrapper retries failed requests.
+// The zero value of each field falls back to the value used by DefaultRetryPolicy.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first one
+	BaseDelay   time.Duration // Delay before the first retry; doubled on every further attempt
+	MaxDelay    time.Duration // Upper bound of every delay; a longer Retry-After ends the retries
+	Jitter      float64       // Fraction (0-1) of each delay that is randomized
+
+	// RetryableStatus lists the HTTP status codes that are retried.
+	RetryableStatus []int
+
+	// IsRetryableError decides whether a transport-level error is retried.
+	// When nil, connection resets, unexpected EOFs and timeouts are retried.
+	IsRetryableError func(err error) bool
+
+	// OnRetry is called before sleeping ahead of every retry, e.g. to count retries.
+	OnRetry func(event RetryEvent)
+}
+
+// RetryEvent describes a retry that is about to happen.
+type RetryEvent struct {
+	Endpoint string        // Endpoint being requested
+	Attempt  int           // Number of the attempt that failed, starting at 1
+	Delay    time.Duration // Time to wait before the next attempt
+	Err      error         // Error returned by the failed attempt
+}
+
+// DefaultRetryPolicy returns a policy that makes up to three attempts with
+// exponential backoff and retries rate-limited and 5xx gateway responses.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// shouldRetry reports whether another attempt should follow the failed attempt.
+// A nil policy never retries.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, attempt int, err error) bool {
+	if p == nil || ctx.Err() != nil {
+		return false
+	}
+
+	maxAttempts := p.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultRetryPolicy().MaxAttempts
+	}
+	if attempt >= maxAttempts {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		// Waiting longer than MaxDelay, possibly until the quota resets hours later,
+		// would block the caller; the APIError carries RetryAfter instead
+		if apiErr.RetryAfter > p.maxDelay() {
+			return false
+		}
+		statuses := p.RetryableStatus
+		if statuses == nil {
+			statuses = DefaultRetryPolicy().RetryableStatus
+		}
+		return slices.Contains(statuses, apiErr.StatusCode)
+	}
+
+	// Refusals by the local quota policy are never worth retrying
+	if errors.Is(err, ErrQuotaExhausted) {
+		return false
+	}
+
+	if p.IsRetryableError != nil {
+		return p.IsRetryableError(err)
+	}
+	return isTransientError(err)
+}
+
+// maxDelay returns the upper bound of every delay.
+func (p *RetryPolicy) maxDelay() time.Duration {
+	if p.MaxDelay <= 0 {
+		return DefaultRetryPolicy().MaxDelay
+	}
+	return p.MaxDelay
+}
+
+// delay computes how long to wait after the given failed attempt.
+// A delay requested by the API takes precedence over the computed backoff;
+// both are capped at MaxDelay.
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	maxDelay := p.maxDelay()
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return min(apiErr.RetryAfter, maxDelay)
+	}
+
+	base := p.BaseDelay
+	if base <= 0 {
+		base = DefaultRetryPolicy().BaseDelay
+	}
+
+	// Exponential backoff: base * 2^(attempt-1), capped at maxDelay
+	d := base
+	for i := 1; i < attempt && d < maxDelay; i++ {
+		d *= 2
+	}
+	d = min(d, maxDelay)
+
+	// Spread the delay over [d*(1-jitter), d*(1+jitter)) to avoid thundering herds
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		d = time.Duration(float64(d) * (1 - jitter + 2*jitter*rand.Float64()))
+	}
+	return d
+}
+
+// isTransientError reports whether a transport-level error is likely to succeed on retry.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryAfter determines how long the API asked the client to wait.
+// It understands both forms of Retry-After and falls back to the rate-limit
+// reset header for 429 responses.
+func retryAfter(resp *http.Response) time.Duration {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return max(time.Until(at), 0)
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if reset, err := strconv.ParseInt(resp.Header.Get(HeaderRateLimitReset), 10, 64); err == nil {
+			return max(time.Until(time.Unix(reset, 0)), 0)
+		}
+	}
+	return 0
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
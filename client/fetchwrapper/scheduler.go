@@ -0,0 +1,316 @@
+package fetchwrapper
+
+import (
+	"container/heap"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Priority orders requests waiting in a Scheduler. Higher priorities are sent first;
+// requests of equal priority are sent in arrival order.
+type Priority int
+
+const (
+	PriorityLow    Priority = -1 // Background work such as crawls
+	PriorityNormal Priority = 0  // Requests without an explicit priority
+	PriorityHigh   Priority = 1  // Interactive lookups that should jump the queue
+)
+
+// priorityKey is the context key under which the request priority is stored.
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx carrying the priority of the requests made with it.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFrom returns the priority carried by ctx, or PriorityNormal.
+func PriorityFrom(ctx context.Context) Priority {
+	if shared, ok := ctx.Value(sharedPriorityKey{}).(*sharedPriority); ok {
+		return shared.get()
+	}
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityNormal
+}
+
+// SchedulerConfig configures a Scheduler. Zero values disable the corresponding limit.
+type SchedulerConfig struct {
+	MaxInFlight int     // Maximum number of requests in flight; 0 means unlimited
+	Rate        float64 // Requests per second allowed by the token bucket; 0 means unlimited
+	Burst       int     // Capacity of the token bucket; defaults to 1 when a rate applies
+
+	// Adaptive derives the rate from the rate-limit headers of each response,
+	// spreading the remaining quota evenly until it resets. Rate applies until
+	// the first response carrying the headers is seen.
+	Adaptive bool
+}
+
+// Scheduler caps the number of requests in flight and paces them with a token bucket.
+// Waiting requests are released by priority. A single scheduler is meant to be shared
+// by every FetchWrapper of a client. It is safe for concurrent use.
+type Scheduler struct {
+	mu          sync.Mutex
+	maxInFlight int
+	adaptive    bool
+	now         func() time.Time
+
+	inFlight int
+	rate     float64   // Tokens added per second; 0 means unlimited
+	burst    float64   // Maximum number of tokens
+	tokens   float64   // Tokens currently available
+	last     time.Time // Time tokens were last added
+
+	queue waitQueue
+	seq   uint64      // Arrival counter used to keep equal priorities in order
+	timer *time.Timer // Pending wake-up for the next token, if any
+}
+
+// NewScheduler creates a Scheduler with the given limits. The bucket starts full.
+func NewScheduler(cfg SchedulerConfig) *Scheduler {
+	burst := float64(cfg.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &Scheduler{
+		maxInFlight: cfg.MaxInFlight,
+		adaptive:    cfg.Adaptive,
+		now:         time.Now,
+		rate:        cfg.Rate,
+		burst:       burst,
+		tokens:      burst,
+		last:        time.Now(),
+	}
+}
+
+// Acquire blocks until a request may be sent, honoring the priority carried by ctx.
+// The returned function must be called once the request has completed.
+func (s *Scheduler) Acquire(ctx context.Context) (release func(), err error) {
+	priority := PriorityFrom(ctx)
+	s.mu.Lock()
+	w := &waiter{priority: priority, seq: s.seq, ready: make(chan struct{})}
+	s.seq++
+	heap.Push(&s.queue, w)
+	s.dispatch()
+	s.mu.Unlock()
+
+	// A request shared by coalesced callers is raised to the priority of later callers
+	if shared, ok := ctx.Value(sharedPriorityKey{}).(*sharedPriority); ok {
+		shared.wait(s, w)
+	}
+
+	select {
+	case <-w.ready:
+		return s.releaser(), nil
+	case <-ctx.Done():
+		s.abandon(w)
+		return nil, ctx.Err()
+	}
+}
+
+// abandon removes a waiter whose context is done. When it lost the race with
+// dispatch and was granted anyway, its slot and token are handed back.
+func (s *Scheduler) abandon(w *waiter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w.granted {
+		s.inFlight--
+		if w.token {
+			s.tokens = min(s.burst, s.tokens+1)
+		}
+	} else {
+		heap.Remove(&s.queue, w.index)
+		w.index = -1
+	}
+	s.dispatch()
+}
+
+// Update adjusts the rate of an adaptive scheduler to the rate-limit headers of a response.
+func (s *Scheduler) Update(header http.Header) {
+	if !s.adaptive {
+		return
+	}
+	limit, ok := parseRateLimit(header)
+	if !ok || limit.Reset.IsZero() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	window := limit.Reset.Sub(now).Seconds()
+	if window <= 0 {
+		return
+	}
+	s.refill(now)
+	if limit.Remaining <= 0 {
+		// Nothing left: the next token becomes available when the quota resets
+		s.rate = 1 / window
+		s.tokens = 0
+	} else {
+		s.rate = float64(limit.Remaining) / window
+		s.tokens = min(s.tokens, float64(limit.Remaining))
+	}
+	s.dispatch()
+}
+
+// raise moves a waiter still in the queue up to the given priority.
+func (s *Scheduler) raise(w *waiter, priority Priority) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w.granted || w.index < 0 || priority <= w.priority {
+		return
+	}
+	w.priority = priority
+	heap.Fix(&s.queue, w.index)
+}
+
+// sharedPriority is the priority of a request made on behalf of several callers, such
+// as a coalesced request. It is the highest priority of the callers and can be raised
+// while the request waits in a Scheduler.
+type sharedPriority struct {
+	mu       sync.Mutex
+	priority Priority
+	sched    *Scheduler // Scheduler the request is waiting in, if any
+	waiter   *waiter
+}
+
+// sharedPriorityKey is the context key under which a *sharedPriority is stored.
+type sharedPriorityKey struct{}
+
+// withSharedPriority returns a copy of ctx whose requests are scheduled at the priority of shared.
+func withSharedPriority(ctx context.Context, shared *sharedPriority) context.Context {
+	return context.WithValue(ctx, sharedPriorityKey{}, shared)
+}
+
+func (p *sharedPriority) get() Priority {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.priority
+}
+
+// raise raises the priority, moving the request up the queue it is waiting in.
+func (p *sharedPriority) raise(priority Priority) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if priority <= p.priority {
+		return
+	}
+	p.priority = priority
+	if p.sched != nil {
+		p.sched.raise(p.waiter, priority)
+	}
+}
+
+// wait records that the request waits in s as w, applying a priority raised in the meantime.
+func (p *sharedPriority) wait(s *Scheduler, w *waiter) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sched, p.waiter = s, w
+	s.raise(w, p.priority)
+}
+
+// releaser returns a function that frees an in-flight slot exactly once.
+func (s *Scheduler) releaser() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.inFlight--
+			s.dispatch()
+		})
+	}
+}
+
+// dispatch grants waiting requests while the limits allow it, and schedules a
+// wake-up when the next one only lacks a token. The caller holds the lock.
+func (s *Scheduler) dispatch() {
+	for s.queue.Len() > 0 {
+		if s.maxInFlight > 0 && s.inFlight >= s.maxInFlight {
+			return // A release dispatches again
+		}
+
+		token := s.rate > 0
+		if token {
+			s.refill(s.now())
+			if s.tokens < 1 {
+				s.wakeAfter(time.Duration((1 - s.tokens) / s.rate * float64(time.Second)))
+				return
+			}
+			s.tokens--
+		}
+
+		w := heap.Pop(&s.queue).(*waiter)
+		w.granted, w.token = true, token
+		s.inFlight++
+		close(w.ready)
+	}
+}
+
+// refill adds the tokens accumulated since the last refill. The caller holds the lock.
+func (s *Scheduler) refill(now time.Time) {
+	if elapsed := now.Sub(s.last).Seconds(); elapsed > 0 {
+		s.tokens = min(s.burst, s.tokens+elapsed*s.rate)
+	}
+	s.last = now
+}
+
+// wakeAfter arranges for dispatch to run again after d. The caller holds the lock.
+func (s *Scheduler) wakeAfter(d time.Duration) {
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(d, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.timer = nil
+		s.dispatch()
+	})
+}
+
+// waiter is a request waiting for the scheduler.
+type waiter struct {
+	priority Priority
+	seq      uint64
+	ready    chan struct{} // Closed when the request may be sent
+	granted  bool          // Set under the scheduler lock when ready is closed
+	token    bool          // A token of the bucket was spent on the grant
+	index    int           // Position in the queue, maintained by container/heap
+}
+
+// waitQueue is a heap of waiters ordered by priority, then arrival.
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waitQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waitQueue) Pop() any {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return w
+}
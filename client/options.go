@@ -0,0 +1,209 @@
+package client
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kumarsgoyal/pexels-go/client/cache"
+	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
+	"github.com/kumarsgoyal/pexels-go/utils"
+)
+
+// Option configures a PexelsClient created by NewClient.
+type Option func(*clientConfig)
+
+// clientConfig collects the settings applied by Options before the client is built.
+type clientConfig struct {
+	httpClient        *http.Client
+	transport         http.RoundTripper
+	timeout           time.Duration
+	timeoutSet        bool
+	photoBaseURL      string
+	videoBaseURL      string
+	collectionBaseURL string
+	userAgentSuffix   string
+	retry             *fetchwrapper.RetryPolicy
+	quotaPolicy       fetchwrapper.QuotaPolicy
+	logger            *slog.Logger
+	cache             cache.Cache
+	offline           bool
+	coalesce          bool
+	scheduler         *fetchwrapper.SchedulerConfig
+}
+
+// defaultConfig returns the settings used when no options are given.
+func defaultConfig() *clientConfig {
+	return &clientConfig{
+		timeout:           fetchwrapper.DefaultTimeout,
+		photoBaseURL:      PhotoBaseURL,
+		videoBaseURL:      VideoBaseURL,
+		collectionBaseURL: CollectionBaseURL,
+	}
+}
+
+// WithHTTPClient makes the client send every request through the given *http.Client.
+// The client is shared by all endpoint groups.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the http.RoundTripper used by the shared HTTP client,
+// e.g. to route requests through a proxy or to instrument them.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cfg *clientConfig) {
+		cfg.transport = transport
+	}
+}
+
+// WithTimeout sets the overall timeout of each HTTP request. Zero means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.timeout = timeout
+		cfg.timeoutSet = true
+	}
+}
+
+// WithBaseURL points all endpoint groups at a different API root, such as a
+// local mock server. The root is expected to serve the same paths as
+// https://api.pexels.com (/v1/, /videos/ and /v1/collections/).
+func WithBaseURL(root string) Option {
+	root = strings.TrimSuffix(root, "/")
+	return func(cfg *clientConfig) {
+		cfg.photoBaseURL = root + "/v1/"
+		cfg.videoBaseURL = root + "/videos/"
+		cfg.collectionBaseURL = root + "/v1/collections/"
+	}
+}
+
+// WithPhotoBaseURL overrides the base URL used for photo endpoints.
+func WithPhotoBaseURL(baseURL string) Option {
+	return func(cfg *clientConfig) {
+		cfg.photoBaseURL = withTrailingSlash(baseURL)
+	}
+}
+
+// WithVideoBaseURL overrides the base URL used for video endpoints.
+func WithVideoBaseURL(baseURL string) Option {
+	return func(cfg *clientConfig) {
+		cfg.videoBaseURL = withTrailingSlash(baseURL)
+	}
+}
+
+// WithCollectionBaseURL overrides the base URL used for collection endpoints.
+func WithCollectionBaseURL(baseURL string) Option {
+	return func(cfg *clientConfig) {
+		cfg.collectionBaseURL = withTrailingSlash(baseURL)
+	}
+}
+
+// WithUserAgent appends a suffix identifying the calling application to the
+// default User-Agent, e.g. "my-app/2.1".
+func WithUserAgent(suffix string) Option {
+	return func(cfg *clientConfig) {
+		cfg.userAgentSuffix = suffix
+	}
+}
+
+// WithRetryPolicy enables retries of transient failures for every endpoint group.
+func WithRetryPolicy(policy *fetchwrapper.RetryPolicy) Option {
+	return func(cfg *clientConfig) {
+		cfg.retry = policy
+	}
+}
+
+// WithQuotaPolicy sets the behavior once the remaining quota reaches zero.
+func WithQuotaPolicy(policy fetchwrapper.QuotaPolicy) Option {
+	return func(cfg *clientConfig) {
+		cfg.quotaPolicy = policy
+	}
+}
+
+// WithLogger sets the structured logger used by the client. Records include the
+// endpoint, status, latency and attempt of each request; the API key is always redacted.
+// By default the client does not log anything.
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *clientConfig) {
+		cfg.logger = logger
+	}
+}
+
+// WithCache serves repeated requests from the given cache, shared by all
+// endpoint groups, e.g. cache.NewMemory(cache.MemoryOptions{}).
+func WithCache(c cache.Cache) Option {
+	return func(cfg *clientConfig) {
+		cfg.cache = c
+	}
+}
+
+// WithOffline makes the client answer every request from the cache configured with
+// WithCache, including expired entries of caches such as cache.Disk, and fail with
+// ErrCacheMiss instead of contacting the API.
+func WithOffline(offline bool) Option {
+	return func(cfg *clientConfig) {
+		cfg.offline = offline
+	}
+}
+
+// WithRequestCoalescing controls whether concurrent requests for the same URL share
+// a single round-trip. It is disabled by default; each caller still decodes its own
+// copy of the response, so results can be modified freely. With WithScheduler, the
+// shared request waits at the highest priority of the callers sharing it.
+func WithRequestCoalescing(enabled bool) Option {
+	return func(cfg *clientConfig) {
+		cfg.coalesce = enabled
+	}
+}
+
+// WithScheduler limits the number of requests in flight and paces them with a
+// token bucket shared by all endpoint groups. Use fetchwrapper.WithPriority on the
+// context of a request to let it jump ahead of waiting lower-priority requests.
+func WithScheduler(config fetchwrapper.SchedulerConfig) Option {
+	return func(cfg *clientConfig) {
+		cfg.scheduler = &config
+	}
+}
+
+// buildLogger returns the logger shared by all fetch wrappers, wrapped so that
+// the API key can never appear in its output.
+func (cfg *clientConfig) buildLogger(apiKey string) *slog.Logger {
+	if cfg.logger == nil {
+		return utils.DiscardLogger()
+	}
+	return slog.New(utils.NewRedactingHandler(cfg.logger.Handler(), apiKey))
+}
+
+// buildHTTPClient returns the single HTTP client shared by all fetch wrappers.
+func (cfg *clientConfig) buildHTTPClient() *http.Client {
+	if cfg.httpClient != nil {
+		// Copy so that transport and timeout options do not mutate the caller's client
+		httpClient := *cfg.httpClient
+		if cfg.transport != nil {
+			httpClient.Transport = cfg.transport
+		}
+		if cfg.timeoutSet {
+			httpClient.Timeout = cfg.timeout
+		}
+		return &httpClient
+	}
+	return &http.Client{Transport: cfg.transport, Timeout: cfg.timeout}
+}
+
+// userAgent returns the User-Agent header value including the optional suffix.
+func (cfg *clientConfig) userAgent() string {
+	if cfg.userAgentSuffix == "" {
+		return fetchwrapper.DefaultUserAgent
+	}
+	return fetchwrapper.DefaultUserAgent + " " + cfg.userAgentSuffix
+}
+
+// withTrailingSlash ensures endpoints can be appended directly to a base URL.
+func withTrailingSlash(baseURL string) string {
+	if strings.HasSuffix(baseURL, "/") {
+		return baseURL
+	}
+	return baseURL + "/"
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kumarsgoyal/pexels-go/client"
+	"github.com/kumarsgoyal/pexels-go/config"
+	"github.com/kumarsgoyal/pexels-go/utils"
+)
+
+// Environment variables read by the command.
+const (
+	envAPIKey = "PEXELS_API_KEY" // API key, taking precedence over the config file
+	envConfig = "PEXELS_CONFIG"  // Default path of the config file
+)
+
+// defaultConfigPath is the config file used when neither --config nor PEXELS_CONFIG is set.
+const defaultConfigPath = ".apiConfig"
+
+// app holds the state shared by the commands of one run.
+type app struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+
+	configPath string
+	baseURL    string
+	timeout    time.Duration
+	verbose    bool
+	output     outputFlags // Flags of the command
+
+	client *client.PexelsClient // Created on first use by pexels
+}
+
+// command is a subcommand, such as "search photos".
+type command struct {
+	name    string
+	args    string // Positional arguments, for the usage message
+	summary string
+	run     func(ctx context.Context, a *app, cmd *command, args []string) error
+}
+
+// commands lists every subcommand, in the order of the usage message.
+var commands = []command{
+	{"search photos", "<query>", "Search photos", searchPhotos},
+	{"search videos", "<query>", "Search videos", searchVideos},
+	{"curated", "", "List curated photos", curated},
+	{"popular", "", "List popular videos", popular},
+	{"get photo", "<id>", "Get a photo", getPhoto},
+	{"get video", "<id>", "Get a video", getVideo},
+	{"collections list", "", "List your collections", collectionsList},
+	{"collections featured", "", "List featured collections", collectionsFeatured},
+	{"collections media", "<id>", "List the media of a collection", collectionsMedia},
+}
+
+// run executes the command line args and returns the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	a := &app{stdout: stdout, stderr: stderr, getenv: getenv}
+	err := a.run(ctx, args)
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case ctx.Err() != nil:
+		err = ctx.Err()
+		fmt.Fprintf(stderr, "pexels: %v\n", err)
+	default:
+		fmt.Fprintf(stderr, "pexels: %v\n", err)
+		var usageErr *usageError
+		if errors.As(err, &usageErr) {
+			fmt.Fprintln(stderr, "Run 'pexels -h' for usage.")
+		}
+	}
+	return exitCode(err)
+}
+
+// run parses the global flags and runs the selected command.
+func (a *app) run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pexels", flag.ContinueOnError)
+	fs.StringVar(&a.configPath, "config", "", "path of the JSON config file holding the API key (default $"+envConfig+" or "+defaultConfigPath+")")
+	fs.StringVar(&a.baseURL, "base-url", "", "root URL of the API, e.g. of a local mock server")
+	fs.DurationVar(&a.timeout, "timeout", 0, "timeout of each request (default 30s)")
+	fs.BoolVar(&a.verbose, "verbose", false, "log requests to stderr")
+	args, err := a.parse(fs, args, false, func() { a.usage(fs) })
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		a.usage(fs)
+		return usagef("missing command")
+	}
+
+	for i := range commands {
+		cmd := &commands[i]
+		words := strings.Fields(cmd.name)
+		if len(args) >= len(words) && slices.Equal(args[:len(words)], words) {
+			return cmd.run(ctx, a, cmd, args[len(words):])
+		}
+	}
+	return usagef("unknown command %q", strings.Join(args[:min(len(args), 2)], " "))
+}
+
+// usage prints the list of commands and the global flags.
+func (a *app) usage(fs *flag.FlagSet) {
+	fmt.Fprintln(a.stderr, "Usage: pexels [global flags] <command> [flags] [arguments]")
+	fmt.Fprintln(a.stderr, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(a.stderr, "  %-32s %s\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.summary)
+	}
+	fmt.Fprintln(a.stderr, "\nGlobal flags:")
+	fs.SetOutput(a.stderr)
+	fs.PrintDefaults()
+	fmt.Fprintln(a.stderr, "\nRun 'pexels <command> -h' for the flags of a command.")
+}
+
+// pexels returns the API client, creating it on first use.
+func (a *app) pexels() (*client.PexelsClient, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+	apiKey, err := a.apiKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []client.Option
+	if a.baseURL != "" {
+		opts = append(opts, client.WithBaseURL(a.baseURL))
+	}
+	if a.timeout > 0 {
+		opts = append(opts, client.WithTimeout(a.timeout))
+	}
+	if a.verbose {
+		opts = append(opts, client.WithLogger(utils.NewLogger(a.stderr, slog.LevelDebug)))
+	}
+	opts = append(opts, client.WithUserAgent("pexels-cli"))
+
+	a.client = client.NewClient(apiKey, opts...)
+	return a.client, nil
+}
+
+// apiKey returns the API key from the environment or, failing that, from the config file.
+// A missing default config file is reported as errMissingKey.
+func (a *app) apiKey() (string, error) {
+	if key := a.getenv(envAPIKey); key != "" {
+		return key, nil
+	}
+
+	path, explicit := a.configPath, true
+	if path == "" {
+		path = a.getenv(envConfig)
+	}
+	if path == "" {
+		path, explicit = defaultConfigPath, false
+	}
+	if _, err := os.Stat(path); !explicit && errors.Is(err, os.ErrNotExist) {
+		return "", errMissingKey
+	}
+
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errMissingKey, err)
+	}
+	return cfg.PexelAPIKey, nil
+}
+
+// writeJSON writes v to stdout as indented JSON.
+func (a *app) writeJSON(v any) error {
+	encoder := json.NewEncoder(a.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// parse parses the flags of fs in args and returns the positional arguments. Unless
+// interspersed is false, flags may follow positional arguments, as in
+// "search photos cats --all". Parse errors are usage errors; -h prints the usage.
+func (a *app) parse(fs *flag.FlagSet, args []string, interspersed bool, usage func()) ([]string, error) {
+	// Errors are reported by run, and the usage only on request
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				fs.SetOutput(a.stderr)
+				usage()
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+
+		rest := fs.Args()
+		terminated := len(rest) < len(args) && args[len(args)-len(rest)-1] == "--"
+		if !interspersed || terminated || len(rest) == 0 {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// pageFlags holds the pagination flags shared by the list commands.
+type pageFlags struct {
+	page    int
+	perPage int
+	all     bool
+	limit   int
+}
+
+// register adds the pagination flags to fs.
+func (p *pageFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&p.page, "page", 0, "page number, or the first page with --all")
+	fs.IntVar(&p.perPage, "per-page", 0, fmt.Sprintf("results per page, at most %d", types.MaxPerPage))
+	fs.BoolVar(&p.all, "all", false, "follow every page")
+	fs.IntVar(&p.limit, "limit", 0, "with --all, stop after `N` items")
+}
+
+// check reports flag combinations that make no sense.
+func (p *pageFlags) check() error {
+	switch {
+	case p.limit < 0:
+		return usagef("--limit must not be negative")
+	case p.limit > 0 && !p.all:
+		return usagef("--limit requires --all")
+	}
+	return nil
+}
+
+// pagination returns the pagination parameters selected by the flags.
+func (p *pageFlags) pagination() types.PaginationParams {
+	return types.PaginationParams{Page: p.page, PerPage: p.perPage}
+}
+
+// flagSet returns the flag set of a command, holding the output flags, whose usage lists
+// its arguments and flags.
+func (a *app) flagSet(cmd *command) (*flag.FlagSet, func()) {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	a.output.register(fs)
+	return fs, func() {
+		fmt.Fprintf(a.stderr, "Usage: pexels %s [flags] %s\n\n%s.\n\nFlags:\n", cmd.name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+}
+
+// searchPhotos runs "search photos".
+func searchPhotos(ctx context.Context, a *app, cmd *command, args []string) error {
+	fs, usage := a.flagSet(cmd)
+	var params types.PhotoSearchParams
+	var pages pageFlags
+	fs.StringVar((*string)(&params.Orientation), "orientation", "", "landscape, portrait or square")
+	fs.StringVar((*string)(&params.Size), "size", "", "minimum size: large, medium or small")
+	fs.StringVar((*string)(&params.Color), "color", "", "named color such as red, or a hex code such as #ffffff")
+	fs.StringVar((*string)(&params.Locale), "locale", "", "locale of the query, e.g. en-US")
+	pages.register(fs)
+
+	query, err := a.parseQuery(fs, args, &pages, usage)
+	if err != nil {
+		return err
+	}
+	params.Query, params.Page, params.PerPage = query, pages.page, pages.perPage
+
+	pexels, err := a.pexels()
+	if err != nil {
+		return err
+	}
+	if pages.all {
+		return writeAll(a, pexels.Photos.SearchAll(ctx, &params, pages.limit))
+	}
+	response, err := pexels.Photos.SearchWithContext(ctx, &params)
+	if err != nil {
+		return err
+	}
+	return writePage(a, response, response.Photos)
+}
+
+// searchVideos runs "search videos".
+func searchVideos(ctx context.Context, a *app, cmd *command, args []string) error {
+	fs, usage := a.flagSet(cmd)
+	var params types.VideoSearchParams
+	var pages pageFlags
+	fs.StringVar((*string)(&params.Orientation), "orientation", "", "landscape, portrait or square")
+	fs.StringVar((*string)(&params.Size), "size", "", "minimum size: large, medium or small")
+	fs.StringVar((*string)(&params.Locale), "locale", "", "locale of the query, e.g. en-US")
+	pages.register(fs)
+
+	query, err := a.parseQuery(fs, args, &pages, usage)
+	if err != nil {
+		return err
+	}
+	params.Query, params.Page, params.PerPage = query, pages.page, pages.perPage
+
+	pexels, err := a.pexels()
+	if err != nil {
+		return err
+	}
+	if pages.all {
+		return writeAll(a, pexels.Videos.SearchAll(ctx, &params, pages.limit))
+	}
+	response, err := pexels.Videos.SearchWithContext(ctx, &params)
+	if err != nil {
+		return err
+	}
+	return writePage(a, response, response.Videos)
+}
+
+// curated runs "curated".
+func curated(ctx context.Context, a *app, cmd *command, args []string) error {
+	fs, usage := a.flagSet(cmd)
+	var pages pageFlags
+	pages.register(fs)
+	if err := a.parseNone(fs, args, &pages, usage); err != nil {
+		return err
+	}
+
+	pexels, err := a.pexels()
+	if err != nil {
+		return err
+	}
+	params := pages.pagination()
+	if pages.all {
+		return writeAll(a, pexels.Photos.CuratedAll(ctx, &params, pages.limit))
+	}
+	response, err := pexels.Photos.CuratedWithContext(ctx, &params)
+	if err != nil {
+		return err
+	}
+	return writePage(a, response, response.Photos)
+}
+
+// popular runs "popular".
+func popular(ctx context.Context, a *app, cmd *command, args []string) error {
+	fs, usage := a.flagSet(cmd)
+	var params types.VideoFilterParams
+	var pages pageFlags
+	fs.IntVar(&params.MinWidth, "min-width", 0, "minimum width in pixels")
+	fs.IntVar(&params.MinHeight, "min-height", 0, "minimum height in pixels")
+	fs.IntVar(&params.MinDuration, "min-duration", 0, "minimum duration in seconds")
+	fs.IntVar(&params.MaxDuration, "max-duration", 0, "maximum duration in seconds")
+	pages.register(fs)
+	if err := a.parseNone(fs, args, &pages, usage); err != nil {
+		return err
+	}
+	params.Page, params.PerPage = pages.page, pages.perPage
+
+	pexels, err := a.pexels()
+	if err != nil {
+		return err
+	}
+	if pages.all {
+		return writeAll(a, pexels.Videos.PopularAll(ctx, &params, pages.limit))
+	}
+	response, err := pexels.Videos.PopularWithContext(ctx, &params)
+	if err != nil {
+		return err
+	}
+	return writePage(a, response, response.Videos)
+}
+
+// getPhoto runs "get photo".
+func getPhoto(ctx context.Context, a *app, cmd *command, args []string) error {
+	fs, usage := a.flagSet(cmd)
+	id, err := a.parseID(fs, args, usage)
+	if err != nil {
+		return err
+	}
+
+	pexels, err := a.pexels()
+	if err != nil {
+		return err
+	}
+	photo, err := pexels.Photos.GetPhotoWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	return writeItem(a, photo)
+}
+
+// getVideo runs "get video".
+func getVideo(ctx context.Context, a *app, cmd *command, args []string) error {
+	fs, usage := a.flagSet(cmd)
+	id, err := a.parseID(fs, args, usage)
+	if err != nil {
+		return err
+	}
+
+	pexels, err := a.pexels()
+	if err != nil {
+		return err
+	}
+	video, err := pexels.Videos.GetVideoWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	return writeItem(a, video)
+}
+
+// collectionsList runs "collections list".
+func collectionsList(ctx context.Context, a *app, cmd *command, args []string) error {
+	fs, usage := a.flagSet(cmd)
+	var pages pageFlags
+	pages.register(fs)
+	if err := a.parseNone(fs, args, &pages, usage); err != nil {
+		return err
+	}
+
+	pexels, err := a.pexels()
+	if err != nil {
+		return err
+	}
+	if pages.all {
+		return writeAll(a, pexels.Collections.ListAll(ctx, pages.pagination(), pages.limit))
+	}
+	response, err := pexels.Collections.AllWithContext(ctx, pages.pagination())
+	if err != nil {
+		return err
+	}
+	return writePage(a, response, response.Collections)
+}
+
+// collectionsFeatured runs "collections featured".
+func collectionsFeatured(ctx context.Context, a *app, cmd *command, args []string) error {
+	fs, usage := a.flagSet(cmd)
+	var pages pageFlags
+	pages.register(fs)
+	if err := a.parseNone(fs, args, &pages, usage); err != nil {
+		return err
+	}
+
+	pexels, err := a.pexels()
+	if err != nil {
+		return err
+	}
+	if pages.all {
+		return writeAll(a, pexels.Collections.FeaturedAll(ctx, pages.pagination(), pages.limit))
+	}
+	response, err := pexels.Collections.FeaturedWithContext(ctx, pages.pagination())
+	if err != nil {
+		return err
+	}
+	return writePage(a, response, response.Collections)
+}
+
+// collectionsMedia runs "collections media".
+func collectionsMedia(ctx context.Context, a *app, cmd *command, args []string) error {
+	fs, usage := a.flagSet(cmd)
+	var params types.MediaParams
+	var pages pageFlags
+	fs.StringVar((*string)(&params.MediaType), "type", "", "only photos or only videos")
+	fs.StringVar((*string)(&params.Sort), "sort", "", "sort order: asc or desc")
+	pages.register(fs)
+
+	positional, err := a.parse(fs, args, true, usage)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("collections media expects one collection ID, got %d arguments", len(positional))
+	}
+	if err := a.check(&pages); err != nil {
+		return err
+	}
+	params.CollectionID, params.Pagination = positional[0], pages.pagination()
+
+	pexels, err := a.pexels()
+	if err != nil {
+		return err
+	}
+	if pages.all {
+		return writeAll(a, pexels.Collections.MediaAll(ctx, params, pages.limit))
+	}
+	response, err := pexels.Collections.MediaWithContext(ctx, params)
+	if err != nil {
+		return err
+	}
+	return writePage(a, response, response.Media)
+}
+
+// check reports invalid pagination and output flags.
+func (a *app) check(pages *pageFlags) error {
+	if err := pages.check(); err != nil {
+		return err
+	}
+	return a.output.check()
+}
+
+// parseQuery parses the flags of a search command and returns the query made of its arguments.
+func (a *app) parseQuery(fs *flag.FlagSet, args []string, pages *pageFlags, usage func()) (string, error) {
+	positional, err := a.parse(fs, args, true, usage)
+	if err != nil {
+		return "", err
+	}
+	if len(positional) == 0 {
+		return "", usagef("%s expects a query", fs.Name())
+	}
+	return strings.Join(positional, " "), a.check(pages)
+}
+
+// parseNone parses the flags of a command without arguments.
+func (a *app) parseNone(fs *flag.FlagSet, args []string, pages *pageFlags, usage func()) error {
+	positional, err := a.parse(fs, args, true, usage)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("%s takes no arguments, got %q", fs.Name(), positional)
+	}
+	return a.check(pages)
+}
+
+// parseID parses the flags of a command whose single argument is a numeric ID.
+func (a *app) parseID(fs *flag.FlagSet, args []string, usage func()) (int, error) {
+	positional, err := a.parse(fs, args, true, usage)
+	if err != nil {
+		return 0, err
+	}
+	if len(positional) != 1 {
+		return 0, usagef("%s expects one ID, got %d arguments", fs.Name(), len(positional))
+	}
+	id, err := strconv.Atoi(positional[0])
+	if err != nil || id <= 0 {
+		return 0, usagef("invalid ID %q", positional[0])
+	}
+	return id, a.output.check()
+}
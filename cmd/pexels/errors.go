@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/kumarsgoyal/pexels-go/client"
+)
+
+// Exit codes, one per class of error.
+const (
+	exitOK          = 0
+	exitError       = 1   // Any other error
+	exitUsage       = 2   // Invalid command, flags or parameters
+	exitAuth        = 3   // Missing or rejected API key
+	exitNotFound    = 4   // The photo, video or collection does not exist
+	exitRateLimited = 5   // The request quota is exhausted
+	exitServer      = 6   // Pexels failed to handle the request
+	exitNetwork     = 7   // The API could not be reached
+	exitInterrupted = 130 // The run was interrupted
+)
+
+// usageError reports an invalid command line.
+type usageError struct {
+	msg string
+}
+
+// Error implements the error interface.
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// usagef returns a usageError with a formatted message.
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// errMissingKey is returned when no API key is configured.
+var errMissingKey = errors.New("no API key: set " + envAPIKey + " or create a config file")
+
+// exitCode returns the exit code of the class of err.
+func exitCode(err error) int {
+	var usageErr *usageError
+	var validationErr *client.ValidationError
+	var netErr net.Error
+	var urlErr *url.Error
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.As(err, &usageErr), errors.As(err, &validationErr):
+		return exitUsage
+	case errors.Is(err, errMissingKey), errors.Is(err, client.ErrUnauthorized):
+		return exitAuth
+	case errors.Is(err, client.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, client.ErrRateLimited), errors.Is(err, client.ErrQuotaExhausted):
+		return exitRateLimited
+	case errors.Is(err, client.ErrServer):
+		return exitServer
+	case errors.As(err, &netErr), errors.As(err, &urlErr), errors.Is(err, context.DeadlineExceeded):
+		return exitNetwork
+	}
+	return exitError
+}
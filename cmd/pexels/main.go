@@ -0,0 +1,46 @@
+// Command pexels queries the Pexels API from the command line.
+//
+// Usage:
+//
+//	pexels [global flags] <command> [flags] [arguments]
+//
+// Commands:
+//
+//	search photos <query>     Search photos
+//	search videos <query>     Search videos
+//	curated                   List curated photos
+//	popular                   List popular videos
+//	get photo <id>            Get a photo
+//	get video <id>            Get a video
+//	collections list          List your collections
+//	collections featured      List featured collections
+//	collections media <id>    List the media of a collection
+//
+// List commands accept --page and --per-page, or --all to follow every page,
+// optionally stopping after --limit items.
+//
+// Results are written as JSON, or in the format selected by --output: table,
+// ndjson, csv, or template with --template. The table and csv formats take
+// --columns, a comma-separated list of field paths such as src.large or user.name.
+//
+// The API key is read from the PEXELS_API_KEY environment variable or, when it
+// is unset, from the JSON config file given by --config (default .apiConfig).
+//
+// The exit status tells the class of error apart: 2 for usage and invalid
+// parameters, 3 for a missing or rejected API key, 4 for a missing resource,
+// 5 for an exhausted rate limit, 6 for server errors, 7 for network failures
+// and 130 for an interrupted run.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(code)
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kumarsgoyal/pexels-go/pexelstest"
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// runCLI runs the command against a fake API with the given environment and returns
+// the exit code and outputs.
+func runCLI(t *testing.T, server *pexelstest.Server, env map[string]string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"--base-url", server.URL}, args...)
+	code := run(context.Background(), args, &stdout, &stderr, func(key string) string { return env[key] })
+	return code, stdout.String(), stderr.String()
+}
+
+// Test that every command reaches its endpoint and prints JSON
+func TestCommands(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+	env := map[string]string{envAPIKey: server.APIKey}
+
+	tests := []struct {
+		args []string
+		path string // Path and query of the request
+	}{
+		{[]string{"search", "photos", "brown", "bear", "--orientation", "landscape", "--per-page", "5"}, "/v1/search?orientation=landscape&per_page=5&query=brown+bear"},
+		{[]string{"search", "videos", "--size", "large", "ocean"}, "/videos/search?query=ocean&size=large"},
+		{[]string{"curated", "--page", "2"}, "/v1/curated?page=2&per_page=15"},
+		{[]string{"popular", "--min-duration", "10"}, "/videos/popular?min_duration=10"},
+		{[]string{"get", "photo", strconv.Itoa(pexelstest.FirstPhotoID)}, "/v1/photos/" + strconv.Itoa(pexelstest.FirstPhotoID) + "?"},
+		{[]string{"get", "video", strconv.Itoa(pexelstest.FirstVideoID)}, "/videos/videos/" + strconv.Itoa(pexelstest.FirstVideoID) + "?"},
+		{[]string{"collections", "list"}, "/v1/collections/?"},
+		{[]string{"collections", "featured", "--per-page", "3"}, "/v1/collections/featured?per_page=3"},
+		{[]string{"collections", "media", pexelstest.FirstCollectionID, "--type", "videos"}, "/v1/collections/" + pexelstest.FirstCollectionID + "?type=videos"},
+	}
+	for _, test := range tests {
+		before := server.RequestCount()
+		code, stdout, stderr := runCLI(t, server, env, test.args...)
+		if code != exitOK {
+			t.Errorf("%v: exit code %d: %s", test.args, code, stderr)
+			continue
+		}
+		if got := server.Requests()[before]; got != test.path {
+			t.Errorf("%v: requested %s, want %s", test.args, got, test.path)
+		}
+		if !json.Valid([]byte(stdout)) {
+			t.Errorf("%v: invalid JSON output: %s", test.args, stdout)
+		}
+	}
+}
+
+// Test following pages with --all and --limit
+func TestAllLimit(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server, map[string]string{envAPIKey: server.APIKey}, "curated", "--all", "--limit", "20", "--per-page", "8")
+	if code != exitOK {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	var photos []types.Photo
+	if err := json.Unmarshal([]byte(stdout), &photos); err != nil {
+		t.Fatalf("Error decoding output: %v", err)
+	}
+	if len(photos) != 20 || server.RequestCount() != 3 {
+		t.Fatalf("Expected 20 photos in 3 requests, got %d in %d", len(photos), server.RequestCount())
+	}
+}
+
+// Test the exit codes of each class of error
+func TestExitCodes(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+	env := map[string]string{envAPIKey: server.APIKey}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		want int
+	}{
+		{"unknown command", env, []string{"delete", "photo"}, exitUsage},
+		{"missing query", env, []string{"search", "photos"}, exitUsage},
+		{"bad ID", env, []string{"get", "photo", "abc"}, exitUsage},
+		{"unknown flag", env, []string{"curated", "--bogus"}, exitUsage},
+		{"limit without all", env, []string{"curated", "--limit", "5"}, exitUsage},
+		{"invalid parameter", env, []string{"search", "photos", "cats", "--per-page", "100"}, exitUsage},
+		{"help", env, []string{"search", "photos", "-h"}, exitOK},
+		{"missing key", map[string]string{envConfig: filepath.Join(t.TempDir(), "missing.json")}, []string{"curated"}, exitAuth},
+		{"rejected key", map[string]string{envAPIKey: "wrong"}, []string{"curated"}, exitAuth},
+		{"not found", env, []string{"get", "video", "999999999"}, exitNotFound},
+	}
+	for _, test := range tests {
+		if code, _, stderr := runCLI(t, server, test.env, test.args...); code != test.want {
+			t.Errorf("%s: exit code %d, want %d: %s", test.name, code, test.want, stderr)
+		}
+	}
+
+	server.SetInterceptor(func(w http.ResponseWriter, r *http.Request) bool {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return true
+	})
+	if code, _, _ := runCLI(t, server, env, "--timeout", "2s", "curated"); code != exitServer {
+		t.Errorf("server error: exit code %d, want %d", code, exitServer)
+	}
+}
+
+// Test reading the API key from a config file
+func TestConfigFile(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"pexelApiKey":"`+server.APIKey+`"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, stderr := runCLI(t, server, nil, "--config", path, "get", "photo", strconv.Itoa(pexelstest.FirstPhotoID))
+	if code != exitOK || !strings.Contains(stdout, `"id": 2014422`) {
+		t.Fatalf("Exit code %d: %s%s", code, stdout, stderr)
+	}
+}
+
+// Test the table, NDJSON, CSV and template output formats
+func TestOutputFormats(t *testing.T) {
+	server := pexelstest.NewServer()
+	defer server.Close()
+	env := map[string]string{envAPIKey: server.APIKey}
+	photoID := strconv.Itoa(pexelstest.FirstPhotoID)
+
+	tests := []struct {
+		name string
+		args []string
+		want func(stdout string) bool
+	}{
+		{"table", []string{"get", "photo", photoID, "-o", "table", "--columns", "id,src.large"}, func(stdout string) bool {
+			lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+			return len(lines) == 2 && strings.HasPrefix(lines[0], "ID       SRC.LARGE") &&
+				strings.HasPrefix(lines[1], photoID+"  https://")
+		}},
+		{"csv", []string{"popular", "--per-page", "2", "--output", "csv", "--columns", "id, user.name,video_files.0.quality"}, func(stdout string) bool {
+			records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+			return err == nil && len(records) == 3 && strings.Join(records[0], ",") == "id,user.name,video_files.0.quality" &&
+				records[1][1] != "" && records[1][2] != ""
+		}},
+		{"media table", []string{"collections", "media", pexelstest.FirstCollectionID, "-o", "table"}, func(stdout string) bool {
+			return strings.HasPrefix(stdout, "TYPE ") && strings.Contains(stdout, "\nPhoto ") && strings.Contains(stdout, "\nVideo ")
+		}},
+		{"template", []string{"curated", "--per-page", "3", "--template", "{{.ID}} {{json .Src.Tiny}}"}, func(stdout string) bool {
+			lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+			return len(lines) == 3 && strings.HasPrefix(lines[0], photoID+` "https://`)
+		}},
+		{"ndjson", []string{"collections", "media", pexelstest.FirstCollectionID, "--per-page", "2", "--all", "--limit", "5", "-o", "ndjson"}, func(stdout string) bool {
+			lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+			var media types.MediaList
+			return len(lines) == 5 && json.Unmarshal([]byte("["+strings.Join(lines, ",")+"]"), &media) == nil && len(media) == 5
+		}},
+	}
+	for _, test := range tests {
+		code, stdout, stderr := runCLI(t, server, env, test.args...)
+		if code != exitOK || !test.want(stdout) {
+			t.Errorf("%s: exit code %d, unexpected output:\n%s%s", test.name, code, stdout, stderr)
+		}
+	}
+
+	invalid := [][]string{
+		{"curated", "-o", "xml"},
+		{"curated", "--columns", "id"},
+		{"curated", "-o", "template"},
+		{"curated", "-o", "csv", "--template", "{{.ID}}"},
+		{"curated", "--template", "{{.ID"},
+		{"curated", "-o", "table", "--columns", "id,src.huge"},
+		{"popular", "-o", "csv", "--columns", "user.name.first"},
+	}
+	for _, args := range invalid {
+		if code, _, stderr := runCLI(t, server, env, args...); code != exitUsage {
+			t.Errorf("%v: exit code %d, want %d: %s", args, code, exitUsage, stderr)
+		}
+	}
+}
+
+// Test that NDJSON is written while the following pages are fetched
+func TestNDJSONStreaming(t *testing.T) {
+	var stdout bytes.Buffer
+	a := &app{stdout: &stdout, output: outputFlags{format: formatNDJSON}}
+	seq := func(yield func(types.Photo, error) bool) {
+		for id := range 3 {
+			if !yield(types.Photo{ID: id + 1}, nil) {
+				return
+			}
+			if lines := strings.Count(stdout.String(), "\n"); lines != id+1 {
+				t.Errorf("%d lines written after %d photos", lines, id+1)
+			}
+		}
+		yield(types.Photo{}, context.Canceled)
+	}
+	if err := writeAll(a, seq); err != context.Canceled {
+		t.Fatalf("Expected the error of the sequence, got %v", err)
+	}
+}
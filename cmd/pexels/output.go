@@ -0,0 +1,474 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// Output formats selected by --output.
+const (
+	formatJSON     = "json"     // Indented JSON: the whole response, or an array with --all
+	formatNDJSON   = "ndjson"   // One compact JSON object per item
+	formatTable    = "table"    // Aligned columns with a header
+	formatCSV      = "csv"      // Comma-separated columns with a header
+	formatTemplate = "template" // A text/template executed for each item
+)
+
+// formats lists the output formats, for the usage message.
+var formats = []string{formatJSON, formatNDJSON, formatTable, formatCSV, formatTemplate}
+
+// Default columns of the table and CSV formats, by kind of item.
+var (
+	photoColumns      = []string{"id", "width", "height", "photographer", "url"}
+	videoColumns      = []string{"id", "width", "height", "duration", "user.name", "url"}
+	collectionColumns = []string{"id", "title", "media_count", "photos_count", "videos_count"}
+	mediaColumns      = []string{"type", "id", "width", "height", "url"}
+)
+
+// outputFlags holds the output flags shared by every command.
+type outputFlags struct {
+	format   string
+	columns  string
+	template string
+
+	tmpl *template.Template // Parsed by check
+}
+
+// register adds the output flags to fs.
+func (o *outputFlags) register(fs *flag.FlagSet) {
+	usage := "output `format`: " + strings.Join(formats, ", ") + " (default json, or template with --template)"
+	fs.StringVar(&o.format, "output", "", usage)
+	fs.StringVar(&o.format, "o", "", "shorthand for --output")
+	fs.StringVar(&o.columns, "columns", "", "comma-separated `fields` of the table and csv formats, e.g. id,src.large,user.name")
+	fs.StringVar(&o.template, "template", "", "Go `template` executed for each item, e.g. '{{.ID}} {{.Src.Large}}'; implies --output template")
+}
+
+// check reports invalid output flags and parses the template.
+func (o *outputFlags) check() error {
+	if o.format == "" {
+		o.format = formatJSON
+		if o.template != "" {
+			o.format = formatTemplate
+		}
+	}
+	switch o.format {
+	case formatJSON, formatNDJSON, formatTable, formatCSV, formatTemplate:
+	default:
+		return usagef("unknown output format %q, want one of %s", o.format, strings.Join(formats, ", "))
+	}
+
+	switch {
+	case o.columns != "" && o.format != formatTable && o.format != formatCSV:
+		return usagef("--columns requires --output table or csv")
+	case o.template != "" && o.format != formatTemplate:
+		return usagef("--template cannot be combined with --output %s", o.format)
+	case o.format == formatTemplate && o.template == "":
+		return usagef("--output template requires --template")
+	}
+
+	if o.template != "" {
+		tmpl, err := template.New("output").Funcs(template.FuncMap{"json": templateJSON}).Parse(o.template)
+		if err != nil {
+			return usagef("invalid template: %v", err)
+		}
+		o.tmpl = tmpl
+	}
+	return nil
+}
+
+// printer writes items one at a time, so that a paginated listing is written while it is fetched.
+type printer interface {
+	print(item any) error
+	close() error
+}
+
+// newPrinter returns the printer of the selected format for items of type T.
+func newPrinter[T any](a *app) (printer, error) {
+	switch a.output.format {
+	case formatNDJSON:
+		return &ndjsonPrinter{w: a.stdout}, nil
+	case formatTemplate:
+		return &templatePrinter{w: a.stdout, tmpl: a.output.tmpl}, nil
+	case formatTable, formatCSV:
+		columns, err := columnsOf[T](a.output.columns)
+		if err != nil {
+			return nil, err
+		}
+		if a.output.format == formatCSV {
+			return &csvPrinter{w: csv.NewWriter(a.stdout), columns: columns}, nil
+		}
+		return &tablePrinter{w: tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0), columns: columns}, nil
+	}
+	return &jsonPrinter{w: a.stdout}, nil
+}
+
+// writePage writes a page of results: the whole response in the JSON format, its items otherwise.
+func writePage[T any](a *app, response any, items []T) error {
+	if a.output.format == formatJSON {
+		return a.writeJSON(response)
+	}
+	p, err := newPrinter[T](a)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := p.print(item); err != nil {
+			return err
+		}
+	}
+	return p.close()
+}
+
+// writeItem writes a single photo or video.
+func writeItem[T any](a *app, item *T) error {
+	return writePage(a, item, []T{*item})
+}
+
+// writeAll writes the items yielded by seq as they arrive; in the JSON format, as an array.
+// The items received before an error are written too.
+func writeAll[T any](a *app, seq iter.Seq2[T, error]) error {
+	p, err := newPrinter[T](a)
+	if err != nil {
+		return err
+	}
+	for item, itemErr := range seq {
+		if itemErr != nil {
+			err = itemErr
+			break
+		}
+		if err := p.print(item); err != nil {
+			return err
+		}
+	}
+	if closeErr := p.close(); closeErr != nil {
+		return closeErr
+	}
+	return err
+}
+
+// jsonPrinter writes the items as an indented JSON array.
+type jsonPrinter struct {
+	w io.Writer
+	n int // Items written
+}
+
+func (p *jsonPrinter) print(item any) error {
+	data, err := marshalItem(item)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if p.n == 0 {
+		buf.WriteString("[\n  ")
+	} else {
+		buf.WriteString(",\n  ")
+	}
+	if err := json.Indent(&buf, data, "  ", "  "); err != nil {
+		return err
+	}
+	p.n++
+	_, err = buf.WriteTo(p.w)
+	return err
+}
+
+func (p *jsonPrinter) close() error {
+	if p.n == 0 {
+		_, err := io.WriteString(p.w, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(p.w, "\n]\n")
+	return err
+}
+
+// ndjsonPrinter writes each item as JSON on its own line.
+type ndjsonPrinter struct {
+	w io.Writer
+}
+
+func (p *ndjsonPrinter) print(item any) error {
+	data, err := marshalItem(item)
+	if err != nil {
+		return err
+	}
+	_, err = p.w.Write(append(data, '\n'))
+	return err
+}
+
+func (p *ndjsonPrinter) close() error { return nil }
+
+// tablePrinter writes the columns of each item, aligned, under a header.
+type tablePrinter struct {
+	w       *tabwriter.Writer
+	columns []string
+	header  bool // Whether the header was written
+}
+
+func (p *tablePrinter) print(item any) error {
+	if !p.header {
+		p.writeHeader()
+	}
+	values, err := columnValues(item, p.columns)
+	if err != nil {
+		return err
+	}
+	for i, value := range values {
+		// Tabs and newlines would break the alignment
+		values[i] = strings.Join(strings.Fields(value), " ")
+	}
+	_, err = fmt.Fprintln(p.w, strings.Join(values, "\t"))
+	return err
+}
+
+func (p *tablePrinter) close() error {
+	if !p.header {
+		p.writeHeader()
+	}
+	return p.w.Flush()
+}
+
+func (p *tablePrinter) writeHeader() {
+	p.header = true
+	fmt.Fprintln(p.w, strings.ToUpper(strings.Join(p.columns, "\t")))
+}
+
+// csvPrinter writes the columns of each item as CSV records under a header.
+type csvPrinter struct {
+	w       *csv.Writer
+	columns []string
+	header  bool // Whether the header was written
+}
+
+func (p *csvPrinter) print(item any) error {
+	if !p.header {
+		p.header = true
+		p.w.Write(p.columns)
+	}
+	values, err := columnValues(item, p.columns)
+	if err != nil {
+		return err
+	}
+	p.w.Write(values)
+	// Flush every record so that paginated listings are written as they are fetched
+	p.w.Flush()
+	return p.w.Error()
+}
+
+func (p *csvPrinter) close() error {
+	if !p.header {
+		p.header = true
+		p.w.Write(p.columns)
+	}
+	p.w.Flush()
+	return p.w.Error()
+}
+
+// templatePrinter executes a template for each item, each output ending with a newline.
+type templatePrinter struct {
+	w    io.Writer
+	tmpl *template.Template
+}
+
+func (p *templatePrinter) print(item any) error {
+	var buf bytes.Buffer
+	if err := p.tmpl.Execute(&buf, item); err != nil {
+		return err
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err := buf.WriteTo(p.w)
+	return err
+}
+
+func (p *templatePrinter) close() error { return nil }
+
+// templateJSON is the "json" function of templates, e.g. {{json .Src}}.
+func templateJSON(v any) (string, error) {
+	data, err := marshalItem(v)
+	return string(data), err
+}
+
+// marshalItem encodes an item as compact JSON. Photos and videos of collection
+// media carry their "type" field, as in the response.
+func marshalItem(item any) ([]byte, error) {
+	media, ok := item.(types.Media)
+	if !ok {
+		return json.Marshal(item)
+	}
+	data, err := json.Marshal(types.MediaList{media})
+	if err != nil {
+		return nil, err
+	}
+	return data[1 : len(data)-1], nil
+}
+
+// columnsOf returns the columns selected by the comma-separated list columns, or the
+// default columns of T. Every column must be a dotted path of JSON field names of T,
+// such as src.large or user.name; list elements are selected by index, as in video_files.0.link.
+func columnsOf[T any](columns string) ([]string, error) {
+	var media bool
+	var defaults []string
+	switch any(new(T)).(type) {
+	case *types.Photo:
+		defaults = photoColumns
+	case *types.Video:
+		defaults = videoColumns
+	case *types.Collection:
+		defaults = collectionColumns
+	case *types.Media:
+		defaults, media = mediaColumns, true
+	}
+	if columns == "" {
+		return defaults, nil
+	}
+
+	var selected []string
+	for _, column := range strings.Split(columns, ",") {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			continue
+		}
+		path := strings.Split(column, ".")
+		var valid bool
+		if media {
+			valid = len(path) == 1 && strings.EqualFold(column, "type") ||
+				validPath(reflect.TypeFor[types.Photo](), path) ||
+				validPath(reflect.TypeFor[types.Video](), path)
+		} else {
+			valid = validPath(reflect.TypeFor[T](), path)
+		}
+		if !valid {
+			return nil, usagef("unknown column %q", column)
+		}
+		selected = append(selected, column)
+	}
+	if len(selected) == 0 {
+		return nil, usagef("--columns selects no column")
+	}
+	return selected, nil
+}
+
+// validPath reports whether path selects a field of values of type t.
+func validPath(t reflect.Type, path []string) bool {
+	for len(path) > 0 {
+		switch t.Kind() {
+		case reflect.Pointer:
+			t = t.Elem()
+			continue
+		case reflect.Struct:
+			field, ok := jsonField(t, path[0])
+			if !ok {
+				return false
+			}
+			t = field.Type
+		case reflect.Slice, reflect.Array:
+			if index, err := strconv.Atoi(path[0]); err != nil || index < 0 {
+				return false
+			}
+			t = t.Elem()
+		case reflect.Interface, reflect.Map:
+			// Decided by the value
+			return true
+		default:
+			return false
+		}
+		path = path[1:]
+	}
+	return true
+}
+
+// jsonField returns the field of the struct type t encoded as name, ignoring case.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		if strings.EqualFold(tag, name) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// columnValues returns the values of the columns of item, read from its JSON encoding.
+// A column missing from item, such as a photo field of a video, is empty.
+func columnValues(item any, columns []string) ([]string, error) {
+	data, err := marshalItem(item)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var record any
+	if err := decoder.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		value := record
+		for _, key := range strings.Split(column, ".") {
+			value = lookup(value, key)
+		}
+		values[i], err = formatValue(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// lookup returns the field key of a decoded JSON object, ignoring case, or the
+// element at index key of a decoded JSON array. It returns nil when there is none.
+func lookup(value any, key string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if field, ok := v[key]; ok {
+			return field
+		}
+		for name, field := range v {
+			if strings.EqualFold(name, key) {
+				return field
+			}
+		}
+	case []any:
+		if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(v) {
+			return v[index]
+		}
+	}
+	return nil
+}
+
+// formatValue formats a decoded JSON value as a table or CSV cell. Objects and
+// arrays are written as compact JSON.
+func formatValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	data, err := json.Marshal(value)
+	return string(data), err
+}
@@ -3,7 +3,6 @@ package config
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 )
 
@@ -12,10 +11,9 @@ type Config struct {
 	PexelAPIKey string `json:"pexelApiKey"` // API key field expected in the JSON config
 }
 
-// LoadConfig loads the config from the specified file path
+// LoadConfig loads the config from the specified file path.
+// It does not log; the returned errors describe what went wrong.
 func LoadConfig(filePath string) (*Config, error) {
-	log.Printf("Attempting to open the config file: %s", filePath)
-
 	// Open the config file
 	file, err := openConfigFile(filePath)
 	if err != nil {
@@ -36,7 +34,6 @@ func LoadConfig(filePath string) (*Config, error) {
 		return nil, err
 	}
 
-	log.Println("Config file decoded and validated successfully.")
 	return config, nil
 }
 
@@ -45,30 +42,24 @@ func openConfigFile(filePath string) (*os.File, error) {
 	// Attempt to open the file for reading
 	file, err := os.Open(filePath)
 	if err != nil {
-		log.Printf("Error opening config file: %s", err)
 		return nil, fmt.Errorf("failed to open config file: %w", err) // Wrap the error for better debugging
 	}
-	log.Println("Config file opened successfully.")
 	return file, nil
 }
 
 // Helper function to close the config file
 func closeConfigFile(file *os.File) {
-	log.Println("Closing the config file.")
 	file.Close() // Close the file to release system resources
 }
 
 // Helper function to decode the JSON into the Config struct
 func decodeConfig(file *os.File) (*Config, error) {
-	log.Println("Decoding the JSON...")
-
 	// Initialize an empty Config struct
 	var config Config
 	decoder := json.NewDecoder(file) // Create a new JSON decoder for the file
 
 	// Decode JSON content into the struct
 	if err := decoder.Decode(&config); err != nil {
-		log.Printf("Error decoding config JSON: %s", err)
 		return nil, fmt.Errorf("failed to decode config JSON: %w", err)
 	}
 
@@ -79,7 +70,6 @@ func decodeConfig(file *os.File) (*Config, error) {
 func validateConfig(config *Config) error {
 	// Check if the API key is missing or empty
 	if config.PexelAPIKey == "" {
-		log.Println("API key is missing in the config file.")
 		return fmt.Errorf("api key is missing in the config file")
 	}
 	return nil
@@ -0,0 +1,251 @@
+// Package download streams photo and video files returned by the Pexels API to disk.
+//
+// A Downloader picks one variant of each photo or video with a PhotoSelector or a
+// VideoSelector and fetches the files with a bounded pool of workers:
+//
+//	downloader := download.New(download.Options{
+//		Dir:   "assets",
+//		Photo: download.PhotoLarge2x,
+//		Progress: func(p download.Progress) {
+//			fmt.Printf("%d/%d bytes\n", p.Total.Written, p.Total.Size)
+//		},
+//	})
+//	results, err := downloader.Photos(ctx, response.Photos)
+//
+// Files are written to a ".part" file next to their destination and renamed into
+// place once complete. An interrupted download, e.g. after ctx is cancelled, leaves
+// its ".part" file behind and the next run resumes it with an HTTP Range request.
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/kumarsgoyal/pexels-go/internal/parallel"
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// DefaultConcurrency is the number of files downloaded at once unless configured otherwise.
+const DefaultConcurrency = 4
+
+// partExt is appended to the destination path of a file while it is being downloaded.
+const partExt = ".part"
+
+// ErrNoVariant is returned for media that have no variant accepted by the selector.
+var ErrNoVariant = errors.New("no matching variant")
+
+// ErrDuplicatePath is returned for tasks whose destination is also the destination of
+// an earlier task; both would otherwise write to the same ".part" file.
+var ErrDuplicatePath = errors.New("duplicate destination")
+
+// Options configures a Downloader. Zero values select the defaults.
+type Options struct {
+	Dir         string                 // Destination directory; defaults to the working directory
+	Concurrency int                    // Maximum number of files downloaded at once; defaults to DefaultConcurrency
+	HTTPClient  *http.Client           // Client used for the downloads; defaults to http.DefaultClient
+	UserAgent   string                 // Optional User-Agent header
+	Photo       PhotoSelector          // Variant of photos to download; defaults to PhotoOriginal
+	Video       VideoSelector          // File of videos to download; defaults to LargestVideo
+	Name        func(task Task) string // Optional file name of a task, relative to Dir
+	Overwrite   bool                   // Download files whose destination already exists instead of skipping them
+	Progress    ProgressFunc           // Optional progress callback
+}
+
+// Task is a single file to download.
+type Task struct {
+	Media    types.Media // The photo or video the file belongs to; may be nil for custom tasks
+	URL      string      // URL of the file
+	FileType string      // MIME type of the file, if known
+	Path     string      // Destination path
+}
+
+// Result is the outcome of a task.
+type Result struct {
+	Task    Task
+	Bytes   int64 // Size of the downloaded file
+	Resumed bool  // The download continued from a ".part" file
+	Skipped bool  // The destination already existed and was left untouched
+	Err     error // Why the download failed, if it did
+}
+
+// Downloader downloads the files of photos and videos. It is safe for concurrent use.
+type Downloader struct {
+	opts Options
+}
+
+// New returns a Downloader configured with opts.
+func New(opts Options) *Downloader {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	if opts.Photo == nil {
+		opts.Photo = PhotoOriginal
+	}
+	if opts.Video == nil {
+		opts.Video = LargestVideo
+	}
+	if opts.Name == nil {
+		opts.Name = DefaultName
+	}
+	return &Downloader{opts: opts}
+}
+
+// Task returns the task downloading the variant of media picked by the configured selector.
+// It returns ErrNoVariant when the selector finds no suitable variant.
+func (d *Downloader) Task(media types.Media) (Task, error) {
+	task := Task{Media: media}
+	ok := false
+	switch m := media.(type) {
+	case *types.Photo:
+		task.URL, ok = d.opts.Photo(m.Src)
+	case *types.Video:
+		var file types.VideoFile
+		if file, ok = d.opts.Video(m.VideoFiles); ok {
+			task.URL, task.FileType = file.Link, file.FileType
+		}
+	}
+	if !ok || task.URL == "" {
+		return Task{}, fmt.Errorf("error selecting a variant of %s: %w", describe(media), ErrNoVariant)
+	}
+	task.Path = filepath.Join(d.opts.Dir, d.opts.Name(task))
+	return task, nil
+}
+
+// Photos downloads the selected variant of every photo. See Download.
+func (d *Downloader) Photos(ctx context.Context, photos []types.Photo) ([]Result, error) {
+	media := make([]types.Media, len(photos))
+	for i := range photos {
+		media[i] = &photos[i]
+	}
+	return d.Download(ctx, media...)
+}
+
+// Videos downloads the selected file of every video. See Download.
+func (d *Downloader) Videos(ctx context.Context, videos []types.Video) ([]Result, error) {
+	media := make([]types.Media, len(videos))
+	for i := range videos {
+		media[i] = &videos[i]
+	}
+	return d.Download(ctx, media...)
+}
+
+// Download downloads the selected variant of every photo and video. The results
+// are in input order; media without a suitable variant fail with ErrNoVariant and
+// media downloaded to the path of earlier media fail with ErrDuplicatePath.
+// The error joins the errors of the failed downloads, if any.
+func (d *Downloader) Download(ctx context.Context, media ...types.Media) ([]Result, error) {
+	tasks := make([]Task, len(media))
+	failed := make([]error, len(media))
+	for i, m := range media {
+		tasks[i], failed[i] = d.Task(m)
+		if failed[i] != nil {
+			tasks[i].Media = m
+		}
+	}
+	return d.run(ctx, tasks, failed)
+}
+
+// Run downloads the given tasks, keeping at most Options.Concurrency downloads in
+// flight. Once ctx is done, the remaining tasks fail with ctx.Err(). The results are
+// in input order and the error joins the errors of the failed downloads, if any.
+// Tasks sharing the destination of an earlier task fail with ErrDuplicatePath.
+func (d *Downloader) Run(ctx context.Context, tasks []Task) ([]Result, error) {
+	return d.run(ctx, tasks, make([]error, len(tasks)))
+}
+
+// run downloads the tasks whose entry in failed is nil and reports the others as failed.
+func (d *Downloader) run(ctx context.Context, tasks []Task, failed []error) ([]Result, error) {
+	rejectDuplicates(tasks, failed)
+	results := make([]Result, len(tasks))
+	tracker := newTracker(tasks, d.opts.Progress)
+	finish := func(i int, result Result) {
+		results[i] = result
+		tracker.finish(i, result.Err)
+	}
+
+	// Tasks that failed before starting are reported as soon as their turn comes
+	started := parallel.ForEach(ctx, len(tasks), d.opts.Concurrency, func(i int) {
+		if failed[i] != nil {
+			finish(i, Result{Task: tasks[i], Err: failed[i]})
+			return
+		}
+		finish(i, d.fetch(ctx, tasks[i], func(written, size int64) { tracker.update(i, written, size) }))
+	})
+	for i := started; i < len(tasks); i++ {
+		err := failed[i]
+		if err == nil {
+			err = ctx.Err()
+		}
+		finish(i, Result{Task: tasks[i], Err: err})
+	}
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	if len(errs) > 0 {
+		return results, fmt.Errorf("%d of %d downloads failed: %w", len(errs), len(tasks), errors.Join(errs...))
+	}
+	return results, nil
+}
+
+// rejectDuplicates marks the tasks whose destination belongs to an earlier task as failed.
+func rejectDuplicates(tasks []Task, failed []error) {
+	first := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		if failed[i] != nil {
+			continue
+		}
+		dest := filepath.Clean(task.Path)
+		if j, ok := first[dest]; ok {
+			failed[i] = fmt.Errorf("error downloading %s: %s is also the destination of task %d: %w", task.URL, task.Path, j, ErrDuplicatePath)
+			continue
+		}
+		first[dest] = i
+	}
+}
+
+// DefaultName names files after the type and ID of their media, e.g. "photo-2014422.jpeg".
+// The extension is taken from the URL or, failing that, from the MIME type.
+// Tasks without media keep the last element of the URL path.
+func DefaultName(task Task) string {
+	urlPath := ""
+	if u, err := url.Parse(task.URL); err == nil {
+		urlPath = u.Path
+	}
+	if task.Media == nil {
+		return path.Base(urlPath)
+	}
+
+	ext := path.Ext(urlPath)
+	if _, subtype, ok := strings.Cut(task.FileType, "/"); ext == "" && ok {
+		ext = "." + subtype
+	}
+	return fmt.Sprintf("%s-%d%s", strings.ToLower(task.Media.MediaType()), task.Media.MediaID(), ext)
+}
+
+// describe names media in error messages.
+func describe(media types.Media) string {
+	if media == nil {
+		return "nil media"
+	}
+	return fmt.Sprintf("%s %d", strings.ToLower(media.MediaType()), media.MediaID())
+}
+
+// exists reports whether a file exists at path.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
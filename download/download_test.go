@@ -0,0 +1,273 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// fileServer serves generated files of the given sizes, keyed by path, and records Range headers.
+type fileServer struct {
+	*httptest.Server
+	files map[string][]byte
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func newFileServer(t *testing.T, sizes map[string]int) *fileServer {
+	t.Helper()
+	fs := &fileServer{files: make(map[string][]byte)}
+	for name, size := range sizes {
+		fs.files[name] = bytes.Repeat([]byte(name[:1]), size)
+	}
+	fs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fs.mu.Lock()
+		fs.ranges = append(fs.ranges, r.Header.Get("Range"))
+		fs.mu.Unlock()
+
+		content, ok := fs.files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(fs.Close)
+	return fs
+}
+
+func readFile(t *testing.T, path string) []byte {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error reading %s: %v", path, err)
+	}
+	return content
+}
+
+// Test downloading photos and videos with the selected variants and aggregate progress
+func TestDownload(t *testing.T) {
+	server := newFileServer(t, map[string]int{
+		"/photos/1/original.jpeg": 100_000,
+		"/photos/1/large.jpeg":    10_000,
+		"/photos/2/original.jpeg": 50_000,
+		"/videos/3/hd.mp4":        200_000,
+		"/videos/3/sd":            80_000,
+	})
+	photos := []types.Photo{
+		{ID: 1, Src: types.PhotoSrc{Original: server.URL + "/photos/1/original.jpeg", Large: server.URL + "/photos/1/large.jpeg"}},
+		{ID: 2, Src: types.PhotoSrc{Original: server.URL + "/photos/2/original.jpeg"}},
+	}
+	video := &types.Video{ID: 3, VideoFiles: []types.VideoFile{
+		{Width: 960, Height: 540, FileType: "video/mp4", Link: server.URL + "/videos/3/sd"},
+		{Width: 1920, Height: 1080, FileType: "video/mp4", Link: server.URL + "/videos/3/hd.mp4"},
+	}}
+
+	var last Progress
+	var reports int
+	dir := t.TempDir()
+	downloader := New(Options{Dir: dir, Concurrency: 2, Progress: func(p Progress) {
+		reports++
+		last = p
+	}})
+
+	results, err := downloader.Download(context.Background(), &photos[0], &photos[1], video)
+	if err != nil {
+		t.Fatalf("Error downloading: %v", err)
+	}
+	want := map[string]string{
+		"photo-1.jpeg": "/photos/1/original.jpeg",
+		"photo-2.jpeg": "/photos/2/original.jpeg",
+		"video-3.mp4":  "/videos/3/hd.mp4",
+	}
+	for i, result := range results {
+		name := filepath.Base(result.Task.Path)
+		if result.Task.Media != []types.Media{&photos[0], &photos[1], video}[i] || want[name] == "" {
+			t.Fatalf("Unexpected result %d: %+v", i, result)
+		}
+		if !bytes.Equal(readFile(t, result.Task.Path), server.files[want[name]]) {
+			t.Errorf("Unexpected content of %s", name)
+		}
+		if result.Bytes != int64(len(server.files[want[name]])) {
+			t.Errorf("Expected %d bytes for %s, got %d", len(server.files[want[name]]), name, result.Bytes)
+		}
+	}
+
+	if reports < 6 || last.Total.Files != 3 || last.Total.Completed != 3 || last.Total.Written != 350_000 || last.Total.Size != 350_000 {
+		t.Fatalf("Unexpected final progress after %d reports: %+v", reports, last.Total)
+	}
+	if parts, _ := filepath.Glob(filepath.Join(dir, "*"+partExt)); len(parts) != 0 {
+		t.Fatalf("Expected no part files, got %v", parts)
+	}
+
+	// Existing files are skipped and a different variant can be selected
+	downloader = New(Options{Dir: dir, Photo: PhotoLarge})
+	results, err = downloader.Photos(context.Background(), photos)
+	if !errors.Is(err, ErrNoVariant) {
+		t.Fatalf("Expected ErrNoVariant for a photo without a large variant, got %v", err)
+	}
+	if !results[0].Skipped || results[1].Err == nil {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+}
+
+// Test that a part file is resumed with a Range request and replaced when the server ignores it
+func TestResume(t *testing.T) {
+	server := newFileServer(t, map[string]int{"/videos/3/hd.mp4": 100_000})
+	video := types.Video{ID: 3, VideoFiles: []types.VideoFile{{Link: server.URL + "/videos/3/hd.mp4"}}}
+	content := server.files["/videos/3/hd.mp4"]
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "video-3.mp4")
+	if err := os.WriteFile(path+partExt, content[:40_000], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var first Progress
+	downloader := New(Options{Dir: dir, Progress: func(p Progress) {
+		if first.Task.URL == "" {
+			first = p
+		}
+	}})
+	results, err := downloader.Videos(context.Background(), []types.Video{video})
+	if err != nil {
+		t.Fatalf("Error resuming download: %v", err)
+	}
+	if !results[0].Resumed || server.ranges[0] != "bytes=40000-" || first.Written != 40_000 {
+		t.Fatalf("Expected a resumed download, got %+v with ranges %v", results[0], server.ranges)
+	}
+	if !bytes.Equal(readFile(t, path), content) {
+		t.Fatal("Resumed file differs from the served file")
+	}
+
+	// A server without range support sends the whole file, which replaces the part file
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer plain.Close()
+
+	path = filepath.Join(dir, "plain.mp4")
+	if err := os.WriteFile(path+partExt, []byte("stale"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results, err = downloader.Run(context.Background(), []Task{{URL: plain.URL + "/video", Path: path}})
+	if err != nil || results[0].Resumed {
+		t.Fatalf("Unexpected result %+v: %v", results[0], err)
+	}
+	if !bytes.Equal(readFile(t, path), content) {
+		t.Fatal("Downloaded file differs from the served file")
+	}
+}
+
+// Test that a response that cannot resume the part file is closed before the file is requested again
+func TestRestart(t *testing.T) {
+	content := bytes.Repeat([]byte("r"), 64<<10)
+	released := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			// A range that does not start at the offset of the part file
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(content[:1024])
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			close(released)
+			return
+		}
+		select {
+		case <-released:
+			w.Write(content)
+		case <-time.After(5 * time.Second):
+			http.Error(w, "the first response is still open", http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "restart.bin")
+	if err := os.WriteFile(path+partExt, content[:100], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results, err := New(Options{}).Run(context.Background(), []Task{{URL: server.URL + "/restart.bin", Path: path}})
+	if err != nil || results[0].Resumed {
+		t.Fatalf("Unexpected result %+v: %v", results[0], err)
+	}
+	if !bytes.Equal(readFile(t, path), content) {
+		t.Fatal("Downloaded file differs from the served file")
+	}
+}
+
+// Test that media downloaded to the destination of earlier media fail instead of sharing a part file
+func TestDuplicatePath(t *testing.T) {
+	server := newFileServer(t, map[string]int{"/photos/1/original.jpeg": 10_000})
+	photo := &types.Photo{ID: 1, Src: types.PhotoSrc{Original: server.URL + "/photos/1/original.jpeg"}}
+
+	results, err := New(Options{Dir: t.TempDir()}).Download(context.Background(), photo, photo)
+	if !errors.Is(err, ErrDuplicatePath) || results[0].Err != nil || !errors.Is(results[1].Err, ErrDuplicatePath) {
+		t.Fatalf("Expected the second photo to fail with ErrDuplicatePath, got %+v: %v", results, err)
+	}
+	if results[1].Task.Media != photo || len(server.ranges) != 1 {
+		t.Fatalf("Expected one request, got %d", len(server.ranges))
+	}
+}
+
+// Test that cancelling a download keeps its part file for a later run
+func TestCancel(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 64<<10)
+	stalled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		w.Write(content[:len(content)/2])
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer stalled.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stalled.bin")
+	downloader := New(Options{Concurrency: 1, Progress: func(p Progress) {
+		if p.Written >= int64(len(content)/2) {
+			cancel()
+		}
+	}})
+
+	tasks := []Task{{URL: stalled.URL + "/stalled.bin", Path: path}, {URL: stalled.URL + "/never.bin", Path: filepath.Join(dir, "never.bin")}}
+	results, err := downloader.Run(ctx, tasks)
+	if !errors.Is(err, context.Canceled) || !errors.Is(results[0].Err, context.Canceled) || !errors.Is(results[1].Err, context.Canceled) {
+		t.Fatalf("Expected cancelled downloads, got %v", err)
+	}
+	if part := readFile(t, path+partExt); len(part) != len(content)/2 {
+		t.Fatalf("Expected %d bytes in the part file, got %d", len(content)/2, len(part))
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("Expected no file at the destination, got %v", err)
+	}
+}
+
+// Test the default file names
+func TestDefaultName(t *testing.T) {
+	tests := []struct {
+		task Task
+		want string
+	}{
+		{Task{Media: &types.Photo{ID: 7}, URL: "https://images.pexels.com/photos/7/pexels-photo-7.jpeg?auto=compress"}, "photo-7.jpeg"},
+		{Task{Media: &types.Video{ID: 9}, URL: "https://videos.pexels.com/video-files/9/file", FileType: "video/mp4"}, "video-9.mp4"},
+		{Task{URL: "https://example.com/assets/logo.png?v=2"}, "logo.png"},
+	}
+	for _, test := range tests {
+		if got := DefaultName(test.task); got != test.want {
+			t.Errorf("DefaultName(%s) = %q, want %q", test.task.URL, got, test.want)
+		}
+	}
+}
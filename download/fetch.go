@@ -0,0 +1,207 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// copyBufferSize is the size of the chunks copied to disk between progress reports.
+const copyBufferSize = 32 << 10
+
+// fetch downloads a single task, resuming its ".part" file when one exists.
+// report is called with the number of bytes on disk and the file size, or -1 if unknown.
+func (d *Downloader) fetch(ctx context.Context, task Task, report func(written, size int64)) Result {
+	result := Result{Task: task}
+	if !d.opts.Overwrite && exists(task.Path) {
+		result.Skipped = true
+		return result
+	}
+
+	written, resumed, err := d.fetchPart(ctx, task, report)
+	result.Bytes, result.Resumed = written, resumed
+	if err != nil {
+		// A cancelled download surfaces as a read error; report the cancellation instead
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		result.Err = fmt.Errorf("error downloading %s: %w", task.URL, err)
+	}
+	return result
+}
+
+// fetchPart streams the file into its ".part" file and renames it into place once complete.
+// The ".part" file is kept when the download fails so that it can be resumed.
+func (d *Downloader) fetchPart(ctx context.Context, task Task, report func(written, size int64)) (int64, bool, error) {
+	if err := os.MkdirAll(filepath.Dir(task.Path), 0o755); err != nil {
+		return 0, false, err
+	}
+	file, err := os.OpenFile(task.Path+partExt, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, false, err
+	}
+	defer file.Close()
+
+	for {
+		offset, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, false, err
+		}
+		written, resumed, restart, err := d.transfer(ctx, task, file, offset, report)
+		if !restart {
+			return written, resumed, err
+		}
+
+		// The server cannot resume the part file: download the whole file again.
+		// Restarts only happen with offset > 0, so the next attempt is the last.
+		if err := truncate(file); err != nil {
+			return 0, false, err
+		}
+	}
+}
+
+// transfer requests the file from offset, the size of the part file, and appends the
+// response to it. It reports restart when the part file cannot be resumed; the response
+// is closed by then, so the connection is released before the file is requested again.
+func (d *Downloader) transfer(ctx context.Context, task Task, file *os.File, offset int64, report func(written, size int64)) (written int64, resumed, restart bool, err error) {
+	resp, err := d.request(ctx, task.URL, offset)
+	if err != nil {
+		return offset, false, false, err
+	}
+	defer resp.Body.Close()
+
+	size := int64(-1)
+	switch {
+	case offset > 0 && resp.StatusCode == http.StatusPartialContent:
+		start, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			return 0, false, true, nil
+		}
+		size = total
+	case offset > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The part file may already hold the whole file
+		if _, total, ok := parseContentRange(resp.Header.Get("Content-Range")); ok && total == offset {
+			report(offset, offset)
+			return offset, true, false, d.commit(file, task.Path)
+		}
+		return 0, false, true, nil
+	case resp.StatusCode == http.StatusOK:
+		// The server ignored the range or there was nothing to resume
+		if offset > 0 {
+			if err := truncate(file); err != nil {
+				return 0, false, false, err
+			}
+			offset = 0
+		}
+		size = resp.ContentLength
+	default:
+		return offset, false, false, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	resumed = offset > 0
+	report(offset, size)
+	written, err = copyWithProgress(file, resp.Body, offset, func(written int64) { report(written, size) })
+	if err != nil {
+		return written, resumed, false, err
+	}
+	if size >= 0 && written != size {
+		return written, resumed, false, fmt.Errorf("received %d of %d bytes: %w", written, size, io.ErrUnexpectedEOF)
+	}
+	return written, resumed, false, d.commit(file, task.Path)
+}
+
+// request sends a GET request for rawURL, asking for the bytes from offset onwards when offset > 0.
+func (d *Downloader) request(ctx context.Context, rawURL string, offset int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	if d.opts.UserAgent != "" {
+		req.Header.Set("User-Agent", d.opts.UserAgent)
+	}
+	return d.opts.HTTPClient.Do(req)
+}
+
+// commit flushes the part file to disk and atomically renames it to its destination.
+func (d *Downloader) commit(file *os.File, dest string) error {
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(dest+partExt, dest)
+}
+
+// copyWithProgress appends src to dst, which already holds offset bytes,
+// calling report with the running total after every chunk.
+func copyWithProgress(dst io.Writer, src io.Reader, offset int64, report func(written int64)) (int64, error) {
+	buf := make([]byte, copyBufferSize)
+	written := offset
+	for {
+		n, readErr := src.Read(buf)
+		if n > 0 {
+			if _, err := dst.Write(buf[:n]); err != nil {
+				return written, err
+			}
+			written += int64(n)
+			report(written)
+		}
+		if readErr == io.EOF {
+			return written, nil
+		}
+		if readErr != nil {
+			return written, readErr
+		}
+	}
+}
+
+// truncate empties a file opened for writing and rewinds it.
+func truncate(file *os.File) error {
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+	_, err := file.Seek(0, io.SeekStart)
+	return err
+}
+
+// parseContentRange parses a Content-Range header of the form "bytes start-end/size"
+// or "bytes */size". The start is -1 in the latter form and the size is -1 when unknown.
+func parseContentRange(header string) (start, size int64, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	rng, total, found := strings.Cut(spec, "/")
+	if !found {
+		return 0, 0, false
+	}
+
+	size = -1
+	if total != "*" {
+		var err error
+		if size, err = strconv.ParseInt(total, 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	if rng == "*" {
+		return -1, size, true
+	}
+	first, _, found := strings.Cut(rng, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, size, true
+}
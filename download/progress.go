@@ -0,0 +1,85 @@
+package download
+
+import "sync"
+
+// ProgressFunc receives progress reports. Calls are serialized, so the function
+// needs no locking of its own, but it should return quickly as it holds up the workers.
+type ProgressFunc func(Progress)
+
+// Progress reports the state of one file and of the whole run.
+type Progress struct {
+	Task    Task
+	Written int64 // Bytes of the file on disk, including resumed bytes
+	Size    int64 // Size of the file, or -1 while unknown
+	Done    bool  // The file is finished, successfully or not
+	Err     error // Why the file failed, when Done
+	Total   Totals
+}
+
+// Totals aggregates the progress of every file of a run.
+type Totals struct {
+	Files     int   // Number of files in the run
+	Completed int   // Files downloaded or skipped
+	Failed    int   // Files that failed
+	Written   int64 // Bytes on disk across all files
+	Size      int64 // Sum of the known file sizes
+}
+
+// tracker accumulates the progress of a run and forwards it to a ProgressFunc.
+type tracker struct {
+	fn    ProgressFunc
+	tasks []Task
+
+	mu      sync.Mutex
+	written []int64
+	sizes   []int64
+	totals  Totals
+}
+
+// newTracker returns a tracker for tasks; fn may be nil.
+func newTracker(tasks []Task, fn ProgressFunc) *tracker {
+	t := &tracker{
+		fn:      fn,
+		tasks:   tasks,
+		written: make([]int64, len(tasks)),
+		sizes:   make([]int64, len(tasks)),
+		totals:  Totals{Files: len(tasks)},
+	}
+	for i := range t.sizes {
+		t.sizes[i] = -1
+	}
+	return t
+}
+
+// update records the bytes written and the size of task i.
+func (t *tracker) update(i int, written, size int64) {
+	if t.fn == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.totals.Written += written - t.written[i]
+	t.written[i] = written
+	if size >= 0 && t.sizes[i] < 0 {
+		t.totals.Size += size
+		t.sizes[i] = size
+	}
+	t.fn(Progress{Task: t.tasks[i], Written: written, Size: t.sizes[i], Total: t.totals})
+}
+
+// finish records the outcome of task i.
+func (t *tracker) finish(i int, err error) {
+	if t.fn == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		t.totals.Failed++
+	} else {
+		t.totals.Completed++
+	}
+	t.fn(Progress{Task: t.tasks[i], Written: t.written[i], Size: t.sizes[i], Done: true, Err: err, Total: t.totals})
+}
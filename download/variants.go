@@ -0,0 +1,47 @@
+package download
+
+import "github.com/kumarsgoyal/pexels-go/types"
+
+// PhotoSelector returns the URL of the photo variant to download,
+// or false when the photo has no suitable variant.
+type PhotoSelector func(src types.PhotoSrc) (string, bool)
+
+// VideoSelector picks the file to download among the files of a video,
+// or returns false when none is suitable.
+type VideoSelector func(files []types.VideoFile) (types.VideoFile, bool)
+
+// Photo variants, one per field of types.PhotoSrc.
+var (
+	PhotoOriginal  = photoVariant(func(src types.PhotoSrc) string { return src.Original })
+	PhotoLarge2x   = photoVariant(func(src types.PhotoSrc) string { return src.Large2x })
+	PhotoLarge     = photoVariant(func(src types.PhotoSrc) string { return src.Large })
+	PhotoMedium    = photoVariant(func(src types.PhotoSrc) string { return src.Medium })
+	PhotoSmall     = photoVariant(func(src types.PhotoSrc) string { return src.Small })
+	PhotoPortrait  = photoVariant(func(src types.PhotoSrc) string { return src.Portrait })
+	PhotoLandscape = photoVariant(func(src types.PhotoSrc) string { return src.Landscape })
+	PhotoTiny      = photoVariant(func(src types.PhotoSrc) string { return src.Tiny })
+)
+
+// photoVariant returns a selector for the variant returned by field.
+func photoVariant(field func(types.PhotoSrc) string) PhotoSelector {
+	return func(src types.PhotoSrc) (string, bool) {
+		link := field(src)
+		return link, link != ""
+	}
+}
+
+// LargestVideo selects the video file with the most pixels, preferring the
+// first one listed on ties. Files without a link are ignored.
+func LargestVideo(files []types.VideoFile) (types.VideoFile, bool) {
+	var best types.VideoFile
+	found := false
+	for _, file := range files {
+		if file.Link == "" {
+			continue
+		}
+		if !found || file.Width*file.Height > best.Width*best.Height {
+			best, found = file, true
+		}
+	}
+	return best, found
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"log"
+	"log/slog"
+	"os"
 
 	"github.com/kumarsgoyal/pexels-go/client"
 	"github.com/kumarsgoyal/pexels-go/config"
@@ -14,8 +16,8 @@ const (
 )
 
 func main() {
-	// Configure logging
-	utils.ConfigureLogging()
+	// Configure structured logging for the client; the library is silent without it
+	logger := utils.NewLogger(os.Stderr, slog.LevelDebug)
 
 	log.Println("Starting the Pexels client application...")
 
@@ -29,7 +31,7 @@ func main() {
 	log.Println("Configuration loaded successfully.")
 
 	// Initialize the Pexels client with the loaded API key
-	pexelsClient := client.NewClient(cfg.PexelAPIKey)
+	pexelsClient := client.NewClient(cfg.PexelAPIKey, client.WithLogger(logger))
 
 	searchParams := &types.PhotoSearchParams{
 		Query:       "elephant",
@@ -0,0 +1,42 @@
+// Package parallel runs indexed work on a bounded number of goroutines.
+package parallel
+
+import (
+	"context"
+	"sync"
+)
+
+// ForEach calls fn for the indexes 0 to n-1, handing them out in order to at most
+// limit goroutines; a limit below 1 runs one call at a time. Once ctx is done no
+// further call starts. ForEach waits for the started calls and returns how many
+// started, so that the indexes from the result on were never passed to fn.
+func ForEach(ctx context.Context, n, limit int, fn func(i int)) int {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(max(limit, 1), n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	next := 0
+dispatch:
+	for ; next < n; next++ {
+		// A worker may already be waiting, which would make select pick at random
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case indexes <- next:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return next
+}
@@ -0,0 +1,58 @@
+package parallel
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Test that ForEach runs every index once without exceeding its limit
+func TestForEach(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	var mu sync.Mutex
+	seen := make(map[int]int)
+	started := ForEach(context.Background(), 20, 3, func(i int) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		inFlight.Add(-1)
+
+		mu.Lock()
+		seen[i]++
+		mu.Unlock()
+	})
+
+	if started != 20 || len(seen) != 20 {
+		t.Fatalf("Expected 20 calls, got %d started and %d seen", started, len(seen))
+	}
+	for i, count := range seen {
+		if count != 1 {
+			t.Fatalf("Expected index %d to run once, got %d", i, count)
+		}
+	}
+	if peak.Load() > 3 {
+		t.Fatalf("Expected at most 3 calls at once, got %d", peak.Load())
+	}
+}
+
+// Test that no call starts once the context is done
+func TestForEachCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	started := ForEach(ctx, 10, 1, func(i int) {
+		calls.Add(1)
+		if i == 2 {
+			cancel()
+		}
+	})
+	if started != 3 || calls.Load() != 3 {
+		t.Fatalf("Expected 3 calls before the cancellation, got %d started and %d calls", started, calls.Load())
+	}
+}
@@ -1,30 +1,37 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"testing"
 
 	"github.com/kumarsgoyal/pexels-go/client"
-	"github.com/kumarsgoyal/pexels-go/config"
+	"github.com/kumarsgoyal/pexels-go/client/cache"
+	"github.com/kumarsgoyal/pexels-go/client/endpoints"
+	"github.com/kumarsgoyal/pexels-go/client/fetchwrapper"
+	"github.com/kumarsgoyal/pexels-go/pexelstest"
 	"github.com/kumarsgoyal/pexels-go/types"
 )
 
 var testClient *client.PexelsClient
 
-// Set up the test client before each test case
-func setup() {
-	// Load the configuration
-	cfg, err := config.LoadConfig(".apiConfig")
-	if err != nil {
-		log.Fatalf("Error loading configuration: %v", err)
-	}
+// Set up the test client against a local fake of the Pexels API before each test case
+func setup(t *testing.T) *pexelstest.Server {
+	t.Helper()
+
+	// Start the fake server and stop it when the test finishes
+	server := pexelstest.NewServer()
+	t.Cleanup(server.Close)
+
 	// Initialize the client
-	testClient = client.NewClient(cfg.PexelAPIKey)
+	testClient = client.NewClient(server.APIKey, client.WithBaseURL(server.URL))
+	return server
 }
 
 // Test photo search functionality
 func TestPhotoSearch(t *testing.T) {
-	setup()
+	setup(t)
 	searchParams := &types.PhotoSearchParams{
 		Query:       "elephant",
 		Orientation: "landscape",
@@ -48,7 +55,7 @@ func TestPhotoSearch(t *testing.T) {
 
 // Test for curated photos
 func TestPhotoCurated(t *testing.T) {
-	setup()
+	setup(t)
 
 	searchParams := &types.PaginationParams{
 		Page:    1,
@@ -70,8 +77,8 @@ func TestPhotoCurated(t *testing.T) {
 
 // Test fetching a single photo by ID
 func TestGetPhotoByID(t *testing.T) {
-	setup()
-	photoID := 2014422
+	setup(t)
+	photoID := pexelstest.FirstPhotoID
 	photosResponse, err := testClient.Photos.GetPhoto(photoID)
 	if err != nil {
 		t.Fatalf("Error fetching photo: %v", err)
@@ -82,7 +89,7 @@ func TestGetPhotoByID(t *testing.T) {
 
 // Test video search functionality
 func TestVideoSearch(t *testing.T) {
-	setup()
+	setup(t)
 	searchParams := &types.VideoSearchParams{
 		Query:       "elephant",
 		Orientation: "landscape",
@@ -118,7 +125,7 @@ func TestVideoSearch(t *testing.T) {
 }
 
 func TestVideosPopular(t *testing.T) {
-	setup()
+	setup(t)
 
 	filterParams := &types.VideoFilterParams{
 		MinWidth:    640,
@@ -157,8 +164,8 @@ func TestVideosPopular(t *testing.T) {
 
 // Test fetching a video by ID
 func TestGetVideoByID(t *testing.T) {
-	setup()
-	videoID := 2499611 // Replace with an actual video ID
+	setup(t)
+	videoID := pexelstest.FirstVideoID
 	video, err := testClient.Videos.GetVideo(videoID)
 	if err != nil {
 		t.Fatalf("Error fetching video details: %v", err)
@@ -181,7 +188,7 @@ func TestGetVideoByID(t *testing.T) {
 
 // Test fetching all collections with pagination
 func TestGetAllCollections(t *testing.T) {
-	setup()
+	setup(t)
 
 	// Define pagination parameters
 	paginationParams := types.PaginationParams{
@@ -208,7 +215,7 @@ func TestGetAllCollections(t *testing.T) {
 
 // Test fetching featured collections
 func TestGetFeaturedCollections(t *testing.T) {
-	setup()
+	setup(t)
 
 	// Define pagination parameters
 	paginationParams := types.PaginationParams{
@@ -235,13 +242,13 @@ func TestGetFeaturedCollections(t *testing.T) {
 
 // Test fetching media from a specific collection (photos or videos)
 func TestGetMediaFromCollection(t *testing.T) {
-	setup()
+	setup(t)
 
 	// Define media parameters
 	mediaParams := types.MediaParams{
-		CollectionID: "5qa21sj", // Replace with a valid collection ID
-		MediaType:    "photos",  // Specify the media type (photos or videos)
-		Sort:         "desc",    // Specify sort order (asc or desc)
+		CollectionID: pexelstest.FirstCollectionID, // ID of a collection served by the fake
+		MediaType:    types.CollectionMediaPhotos,  // Specify the media type (photos or videos)
+		Sort:         types.SortDesc,               // Specify sort order (asc or desc)
 		Pagination: types.PaginationParams{
 			Page:    1,
 			PerPage: 5,
@@ -256,6 +263,280 @@ func TestGetMediaFromCollection(t *testing.T) {
 
 	// Log the media details
 	for _, media := range mediaResponse.Media {
-		t.Logf("Media ID: %d, URL: %s", media.ID, media.URL)
+		t.Logf("Media ID: %d, URL: %s", media.MediaID(), media.MediaURL())
+	}
+}
+
+// Test typed photo and video helpers over mixed collection media
+func TestCollectionMediaTypes(t *testing.T) {
+	server := setup(t)
+	ctx := context.Background()
+	params := types.MediaParams{CollectionID: pexelstest.FirstCollectionID}
+
+	photos, err := testClient.Collections.MediaPhotos(ctx, params)
+	if err != nil {
+		t.Fatalf("Error fetching collection photos: %v", err)
+	}
+	videos, err := testClient.Collections.MediaVideos(ctx, params)
+	if err != nil {
+		t.Fatalf("Error fetching collection videos: %v", err)
+	}
+
+	all := server.Fixtures.Media[pexelstest.FirstCollectionID]
+	if len(photos) != len(all.Photos()) || len(videos) != len(all.Videos()) {
+		t.Fatalf("Expected %d photos and %d videos, got %d and %d",
+			len(all.Photos()), len(all.Videos()), len(photos), len(videos))
+	}
+	if len(videos) == 0 || len(videos[0].VideoFiles) == 0 {
+		t.Fatalf("Expected videos with files, got %+v", videos)
+	}
+
+	count := 0
+	for media, err := range testClient.Collections.MediaAll(ctx, params, 0) {
+		if err != nil {
+			t.Fatalf("Error iterating collection media: %v", err)
+		}
+		switch m := media.(type) {
+		case *types.Photo:
+			if m.Src.Original == "" {
+				t.Errorf("Photo %d has no source URLs", m.ID)
+			}
+		case *types.Video:
+			if m.Creator().Name == "" {
+				t.Errorf("Video %d has no creator", m.ID)
+			}
+		}
+		count++
+	}
+	if count != len(all) {
+		t.Fatalf("Expected %d media, got %d", len(all), count)
+	}
+}
+
+// Test that a missing photo is reported as a not-found API error
+func TestGetPhotoNotFound(t *testing.T) {
+	setup(t)
+
+	_, err := testClient.Photos.GetPhoto(1)
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "Not Found" {
+		t.Fatalf("Expected an APIError with the Pexels message, got %v", err)
+	}
+}
+
+// Test that an invalid API key is reported as unauthorized
+func TestUnauthorized(t *testing.T) {
+	server := setup(t)
+	testClient = client.NewClient("wrong-key", client.WithBaseURL(server.URL))
+
+	_, err := testClient.Photos.Curated(nil)
+	if !errors.Is(err, client.ErrUnauthorized) {
+		t.Fatalf("Expected ErrUnauthorized, got %v", err)
+	}
+}
+
+// Test that the rate-limit headers are exposed by the client
+func TestRateLimit(t *testing.T) {
+	setup(t)
+
+	if _, ok := testClient.RateLimit(); ok {
+		t.Fatal("Expected no rate limit before the first request")
+	}
+	if _, err := testClient.Photos.Curated(nil); err != nil {
+		t.Fatalf("Error fetching curated photos: %v", err)
+	}
+
+	limit, ok := testClient.RateLimit()
+	if !ok || limit.Limit != pexelstest.DefaultRateLimit || limit.Remaining != pexelstest.DefaultRateLimit-1 {
+		t.Fatalf("Unexpected rate limit: %+v", limit)
+	}
+}
+
+// Test paging through search results with the iterator, following next_page links
+func TestPhotoSearchAll(t *testing.T) {
+	server := setup(t)
+
+	params := &types.PhotoSearchParams{Query: "elephant", PerPage: 40}
+	count := 0
+	for photo, err := range testClient.Photos.SearchAll(context.Background(), params, 0) {
+		if err != nil {
+			t.Fatalf("Error iterating photos: %v", err)
+		}
+		if photo.ID != pexelstest.FirstPhotoID+count {
+			t.Fatalf("Unexpected photo %d at position %d", photo.ID, count)
+		}
+		count++
+	}
+	if count != pexelstest.DefaultPhotoCount {
+		t.Fatalf("Expected %d photos, got %d", pexelstest.DefaultPhotoCount, count)
+	}
+	if server.RequestCount() != 3 {
+		t.Fatalf("Expected 3 page requests, got %d", server.RequestCount())
+	}
+
+	// Capping the number of items stops fetching further pages
+	count = 0
+	for _, err := range testClient.Videos.PopularAll(context.Background(), &types.VideoFilterParams{PerPage: 5}, 7) {
+		if err != nil {
+			t.Fatalf("Error iterating videos: %v", err)
+		}
+		count++
+	}
+	if count != 7 {
+		t.Fatalf("Expected 7 videos, got %d", count)
+	}
+}
+
+// Test following next and previous page links
+func TestNextAndPrevPage(t *testing.T) {
+	setup(t)
+	ctx := context.Background()
+
+	first, err := testClient.Collections.All(types.PaginationParams{PerPage: 10})
+	if err != nil {
+		t.Fatalf("Error fetching collections: %v", err)
+	}
+	if _, err := testClient.Collections.PrevPage(ctx, first); !errors.Is(err, client.ErrNoMorePages) {
+		t.Fatalf("Expected ErrNoMorePages before the first page, got %v", err)
+	}
+
+	second, err := testClient.Collections.NextPage(ctx, first)
+	if err != nil {
+		t.Fatalf("Error fetching the next page: %v", err)
+	}
+	if second.Page != 2 || second.Collections[0].ID == first.Collections[0].ID {
+		t.Fatalf("Unexpected second page: %+v", second)
+	}
+
+	back, err := testClient.Collections.PrevPage(ctx, second)
+	if err != nil {
+		t.Fatalf("Error fetching the previous page: %v", err)
+	}
+	if back.Page != 1 || back.Collections[0].ID != first.Collections[0].ID {
+		t.Fatalf("Unexpected previous page: %+v", back)
+	}
+}
+
+// Test that repeated requests are answered from the response cache
+func TestResponseCache(t *testing.T) {
+	server := setup(t)
+	memory := cache.NewMemory(cache.MemoryOptions{})
+	testClient = client.NewClient(server.APIKey, client.WithBaseURL(server.URL), client.WithCache(memory))
+
+	for i := 0; i < 3; i++ {
+		photo, err := testClient.Photos.GetPhoto(pexelstest.FirstPhotoID)
+		if err != nil {
+			t.Fatalf("Error fetching photo: %v", err)
+		}
+		if photo.ID != pexelstest.FirstPhotoID {
+			t.Fatalf("Unexpected photo %d", photo.ID)
+		}
+	}
+	if server.RequestCount() != 1 {
+		t.Fatalf("Expected 1 request, got %d", server.RequestCount())
+	}
+
+	// Errors are never cached
+	for i := 0; i < 2; i++ {
+		if _, err := testClient.Photos.GetPhoto(1); !errors.Is(err, client.ErrNotFound) {
+			t.Fatalf("Expected ErrNotFound, got %v", err)
+		}
+	}
+	if stats := memory.Stats(); stats.Hits != 2 || stats.Entries != 1 || server.RequestCount() != 3 {
+		t.Fatalf("Unexpected cache stats %+v after %d requests", stats, server.RequestCount())
+	}
+}
+
+// Test that a disk cache filled by one client serves an offline client after the API is gone
+func TestOfflineDiskCache(t *testing.T) {
+	server := setup(t)
+	dir := t.TempDir()
+
+	disk, err := cache.NewDisk(cache.DiskOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("Error creating disk cache: %v", err)
+	}
+	testClient = client.NewClient(server.APIKey, client.WithBaseURL(server.URL), client.WithCache(disk))
+	if _, err := testClient.Photos.GetPhoto(pexelstest.FirstPhotoID); err != nil {
+		t.Fatalf("Error fetching photo: %v", err)
+	}
+	server.Close()
+
+	// A new process reuses the directory without any network access
+	disk, err = cache.NewDisk(cache.DiskOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("Error reopening disk cache: %v", err)
+	}
+	offline := client.NewClient(server.APIKey, client.WithBaseURL(server.URL), client.WithCache(disk), client.WithOffline(true))
+
+	photo, err := offline.Photos.GetPhoto(pexelstest.FirstPhotoID)
+	if err != nil || photo.ID != pexelstest.FirstPhotoID {
+		t.Fatalf("Expected the cached photo, got %v, %v", photo, err)
+	}
+
+	_, err = offline.Photos.GetPhoto(pexelstest.FirstPhotoID + 1)
+	var missErr *client.CacheMissError
+	if !errors.Is(err, client.ErrCacheMiss) || !errors.As(err, &missErr) {
+		t.Fatalf("Expected a cache miss, got %v", err)
+	}
+}
+
+// Test batch lookups: input order, per-ID errors and integration with the cache and scheduler
+func TestGetMany(t *testing.T) {
+	server := setup(t)
+	testClient = client.NewClient(server.APIKey, client.WithBaseURL(server.URL),
+		client.WithCache(cache.NewMemory(cache.MemoryOptions{})),
+		client.WithRequestCoalescing(true),
+		client.WithScheduler(fetchwrapper.SchedulerConfig{MaxInFlight: 2}))
+
+	first := pexelstest.FirstPhotoID
+	ids := []int{first + 3, 1, first, first + 3, first + 1}
+	batch, err := testClient.Photos.GetMany(context.Background(), ids, &endpoints.BatchOptions{Concurrency: 3})
+
+	var batchErr *client.BatchError
+	if !errors.As(err, &batchErr) || !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("Expected a batch error wrapping ErrNotFound, got %v", err)
+	}
+	if len(batchErr.NotFound) != 1 || batchErr.NotFound[0] != 1 || len(batch.Failed()) != 0 {
+		t.Fatalf("Unexpected failures: %+v", batchErr)
+	}
+	for i, result := range batch.Results {
+		if result.ID != ids[i] || (result.Err == nil && result.Item.ID != ids[i]) {
+			t.Fatalf("Unexpected result %d: %+v", i, result)
+		}
+	}
+	if len(batch.Items()) != 4 {
+		t.Fatalf("Expected 4 photos, got %d", len(batch.Items()))
+	}
+
+	// The duplicate ID is served by the cache or shared with the identical request in flight
+	if server.RequestCount() != 4 {
+		t.Fatalf("Expected 4 requests, got %d", server.RequestCount())
+	}
+
+	videos, err := testClient.Videos.GetMany(context.Background(), []int{pexelstest.FirstVideoID}, nil)
+	if err != nil || videos.Results[0].Item.ID != pexelstest.FirstVideoID {
+		t.Fatalf("Unexpected video batch: %+v, %v", videos, err)
+	}
+}
+
+// Test that invalid parameters are rejected before any request is sent
+func TestValidationBeforeRequest(t *testing.T) {
+	server := setup(t)
+
+	_, err := testClient.Photos.Search(&types.PhotoSearchParams{Query: "cat", PerPage: 200})
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "per_page" {
+		t.Fatalf("Expected a per_page validation error, got %v", err)
+	}
+	if _, err := testClient.Collections.Media(types.MediaParams{CollectionID: pexelstest.FirstCollectionID, Sort: "random"}); !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+	if server.RequestCount() != 0 {
+		t.Fatalf("Expected no request to be sent, got %d", server.RequestCount())
 	}
 }
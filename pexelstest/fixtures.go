@@ -0,0 +1,178 @@
+package pexelstest
+
+import (
+	"fmt"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// Sizes of the default fixture set.
+const (
+	DefaultPhotoCount      = 120 // Number of photos served by search, curated and photo lookups
+	DefaultVideoCount      = 60  // Number of videos served by search, popular and video lookups
+	DefaultCollectionCount = 24  // Number of collections served by the collection endpoints
+)
+
+// Well-known IDs present in the default fixtures.
+const (
+	FirstPhotoID      = 2014422   // ID of the first photo fixture
+	FirstVideoID      = 2499611   // ID of the first video fixture
+	FirstCollectionID = "5qa21sj" // ID of the first collection fixture
+)
+
+// Fixtures holds the data served by a Server. Tests may modify it before issuing requests.
+type Fixtures struct {
+	Photos      []types.Photo              // Photos in the order returned by search and curated
+	Videos      []types.Video              // Videos in the order returned by search and popular
+	Collections []types.Collection         // Collections in the order returned by the collections endpoint
+	Featured    []string                   // IDs of the featured collections
+	Media       map[string]types.MediaList // Media of each collection, keyed by collection ID
+}
+
+// photographers and their IDs cycle through the generated fixtures.
+var photographers = []struct {
+	id   int
+	name string
+}{
+	{680589, "Pixabay"},
+	{1437723, "Lukas Rodriguez"},
+	{3149039, "Anni Roenkae"},
+	{234562, "Min An"},
+	{88414, "Sebastian Voortman"},
+}
+
+// avgColors are typical average colors of Pexels photos.
+var avgColors = []string{"#978E82", "#4C5B3E", "#A3B8C8", "#2F2B28", "#D8C6A9"}
+
+// NewFixtures generates the default, deterministic fixture set.
+func NewFixtures() *Fixtures {
+	f := &Fixtures{Media: make(map[string]types.MediaList)}
+
+	for i := 0; i < DefaultPhotoCount; i++ {
+		f.Photos = append(f.Photos, newPhoto(FirstPhotoID+i, i))
+	}
+	for i := 0; i < DefaultVideoCount; i++ {
+		f.Videos = append(f.Videos, newVideo(FirstVideoID+i, i))
+	}
+
+	for i := 0; i < DefaultCollectionCount; i++ {
+		id := FirstCollectionID
+		if i > 0 {
+			id = fmt.Sprintf("c%06d", i)
+		}
+
+		// Each collection holds a mix of photos and videos taken from the fixtures
+		var media types.MediaList
+		photos, videos := 0, 0
+		for j := 0; j < 10+i%5; j++ {
+			if j%3 == 2 {
+				video := f.Videos[(i+j)%len(f.Videos)]
+				media = append(media, &video)
+				videos++
+			} else {
+				photo := f.Photos[(i*7+j)%len(f.Photos)]
+				media = append(media, &photo)
+				photos++
+			}
+		}
+		f.Media[id] = media
+
+		f.Collections = append(f.Collections, types.Collection{
+			ID:          id,
+			Title:       fmt.Sprintf("Collection %d", i+1),
+			Description: fmt.Sprintf("Hand-picked media, volume %d", i+1),
+			Private:     false,
+			MediaCount:  len(media),
+			PhotosCount: photos,
+			VideosCount: videos,
+		})
+		if i%2 == 0 {
+			f.Featured = append(f.Featured, id)
+		}
+	}
+	return f
+}
+
+// newPhoto generates a photo fixture with realistic source URLs.
+func newPhoto(id, i int) types.Photo {
+	p := photographers[i%len(photographers)]
+	original := fmt.Sprintf("https://images.pexels.com/photos/%d/pexels-photo-%d.jpeg", id, id)
+	width, height := 6000, 4000
+	if i%4 == 3 {
+		width, height = 3000, 4500
+	}
+
+	return types.Photo{
+		ID:              id,
+		Width:           width,
+		Height:          height,
+		URL:             fmt.Sprintf("https://www.pexels.com/photo/photo-%d/", id),
+		Photographer:    p.name,
+		PhotographerID:  p.id,
+		PhotographerURL: fmt.Sprintf("https://www.pexels.com/@user-%d", p.id),
+		AvgColor:        avgColors[i%len(avgColors)],
+		Src: types.PhotoSrc{
+			Original:  original,
+			Large2x:   original + "?auto=compress&cs=tinysrgb&dpr=2&h=650&w=940",
+			Large:     original + "?auto=compress&cs=tinysrgb&h=650&w=940",
+			Medium:    original + "?auto=compress&cs=tinysrgb&h=350",
+			Small:     original + "?auto=compress&cs=tinysrgb&h=130",
+			Portrait:  original + "?auto=compress&cs=tinysrgb&fit=crop&h=1200&w=800",
+			Landscape: original + "?auto=compress&cs=tinysrgb&fit=crop&h=627&w=1200",
+			Tiny:      original + "?auto=compress&cs=tinysrgb&dpr=1&fit=crop&h=200&w=280",
+		},
+		Alt: fmt.Sprintf("Photo %d by %s", id, p.name),
+	}
+}
+
+// newVideo generates a video fixture with the usual spread of renditions.
+func newVideo(id, i int) types.Video {
+	p := photographers[i%len(photographers)]
+	fps := []float64{25, 29.97, 30, 50, 60}[i%5]
+
+	files := []types.VideoFile{
+		{ID: id*10 + 1, Quality: "uhd", FileType: "video/mp4", Width: 3840, Height: 2160, FPS: fps},
+		{ID: id*10 + 2, Quality: "hd", FileType: "video/mp4", Width: 1920, Height: 1080, FPS: fps},
+		{ID: id*10 + 3, Quality: "hd", FileType: "video/mp4", Width: 1280, Height: 720, FPS: fps},
+		{ID: id*10 + 4, Quality: "sd", FileType: "video/mp4", Width: 960, Height: 540, FPS: fps},
+		{ID: id*10 + 5, Quality: "sd", FileType: "video/mp4", Width: 640, Height: 360, FPS: fps},
+		// Adaptive streams are reported without a quality or dimensions
+		{ID: id*10 + 6, FileType: "video/hls", Link: fmt.Sprintf("https://player.vimeo.com/external/%d.m3u8", id)},
+	}
+	width, height := 3840, 2160
+	if i%3 == 2 {
+		// Portrait videos swap the dimensions of every rendition
+		width, height = 2160, 3840
+		for j := range files {
+			files[j].Width, files[j].Height = files[j].Height, files[j].Width
+		}
+	}
+	for j := range files {
+		if files[j].Link == "" {
+			files[j].Link = fmt.Sprintf("https://videos.pexels.com/video-files/%d/%d-%s_%d_%d_%gfps.mp4",
+				id, id, files[j].Quality, files[j].Width, files[j].Height, files[j].FPS)
+		}
+	}
+
+	var pictures []types.VideoPicture
+	for nr := 0; nr < 3; nr++ {
+		pictures = append(pictures, types.VideoPicture{
+			ID:      id*100 + nr,
+			Picture: fmt.Sprintf("https://images.pexels.com/videos/%d/pictures/preview-%d.jpg", id, nr),
+			NR:      nr,
+		})
+	}
+
+	return types.Video{
+		ID:            id,
+		Width:         width,
+		Height:        height,
+		URL:           fmt.Sprintf("https://www.pexels.com/video/video-%d/", id),
+		Image:         fmt.Sprintf("https://images.pexels.com/videos/%d/free-video-%d.jpg", id, id),
+		Tags:          []string{},
+		Duration:      5 + (i*7)%120,
+		User:          types.User{ID: p.id, Name: p.name, URL: fmt.Sprintf("https://www.pexels.com/@user-%d", p.id)},
+		VideoFiles:    files,
+		VideoPictures: pictures,
+	}
+}
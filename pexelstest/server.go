@@ -0,0 +1,377 @@
+// Package pexelstest provides a local fake of the Pexels API for hermetic tests.
+//
+// A Server emulates the photo, video and collection endpoints, serves deterministic
+// fixtures, honors the page and per_page parameters, returns absolute next_page and
+// prev_page links and reports rate-limit headers like the real API:
+//
+//	server := pexelstest.NewServer()
+//	defer server.Close()
+//	pexelsClient := client.NewClient(server.APIKey, client.WithBaseURL(server.URL))
+package pexelstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// Defaults mirroring the behavior of the Pexels API.
+const (
+	DefaultAPIKey    = "pexelstest-api-key" // API key accepted by a new Server
+	DefaultPerPage   = 15                   // Results per page when per_page is omitted
+	MaxPerPage       = 80                   // Largest accepted per_page value
+	DefaultRateLimit = 20000                // Value reported in X-Ratelimit-Limit
+)
+
+// Interceptor can take over a request before the fake handles it, e.g. to inject failures.
+// It reports whether it wrote a response.
+type Interceptor func(w http.ResponseWriter, r *http.Request) bool
+
+// Server is an httptest.Server emulating the Pexels API.
+type Server struct {
+	*httptest.Server
+
+	APIKey   string    // API key expected in the Authorization header
+	Fixtures *Fixtures // Data served by the fake; may be modified before issuing requests
+
+	mu          sync.Mutex
+	requests    []string
+	remaining   int
+	interceptor Interceptor
+}
+
+// NewServer starts a fake Pexels API serving the default fixtures.
+// The caller must call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:    DefaultAPIKey,
+		Fixtures:  NewFixtures(),
+		remaining: DefaultRateLimit,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/search", s.handlePhotoSearch)
+	mux.HandleFunc("GET /v1/curated", s.handleCurated)
+	mux.HandleFunc("GET /v1/photos/{id}", s.handlePhoto)
+	mux.HandleFunc("GET /videos/search", s.handleVideoSearch)
+	mux.HandleFunc("GET /videos/popular", s.handlePopular)
+	mux.HandleFunc("GET /videos/videos/{id}", s.handleVideo)
+	mux.HandleFunc("GET /v1/collections", s.handleCollections)
+	mux.HandleFunc("GET /v1/collections/{$}", s.handleCollections)
+	mux.HandleFunc("GET /v1/collections/featured", s.handleFeatured)
+	mux.HandleFunc("GET /v1/collections/{id}", s.handleCollectionMedia)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// SetInterceptor installs a function that sees every request before the fake does.
+// Passing nil removes it.
+func (s *Server) SetInterceptor(interceptor Interceptor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interceptor = interceptor
+}
+
+// Requests returns the path and query of every request received so far.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// RequestCount returns the number of requests received so far.
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+// middleware records requests, runs the interceptor, checks the API key and
+// sets the rate-limit headers before dispatching to the endpoint handlers.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.URL.RequestURI())
+		interceptor := s.interceptor
+		s.mu.Unlock()
+
+		if interceptor != nil && interceptor(w, r) {
+			return
+		}
+
+		if r.Header.Get("Authorization") != s.APIKey {
+			writeError(w, http.StatusUnauthorized, "Authorization field missing or invalid")
+			return
+		}
+
+		s.mu.Lock()
+		if s.remaining > 0 {
+			s.remaining--
+		}
+		remaining := s.remaining
+		s.mu.Unlock()
+
+		reset := time.Now().Add(time.Hour).Truncate(time.Hour)
+		w.Header().Set("X-Ratelimit-Limit", strconv.Itoa(DefaultRateLimit))
+		w.Header().Set("X-Ratelimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handlePhotoSearch(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("query") == "" {
+		writeError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+	s.writePhotos(w, r, s.Fixtures.Photos)
+}
+
+func (s *Server) handleCurated(w http.ResponseWriter, r *http.Request) {
+	s.writePhotos(w, r, s.Fixtures.Photos)
+}
+
+func (s *Server) handlePhoto(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	for _, photo := range s.Fixtures.Photos {
+		if photo.ID == id {
+			writeJSON(w, photo)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) handleVideoSearch(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("query") == "" {
+		writeError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+	s.writeVideos(w, r, s.Fixtures.Videos)
+}
+
+func (s *Server) handlePopular(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	minWidth, _ := strconv.Atoi(query.Get("min_width"))
+	minHeight, _ := strconv.Atoi(query.Get("min_height"))
+	minDuration, _ := strconv.Atoi(query.Get("min_duration"))
+	maxDuration, _ := strconv.Atoi(query.Get("max_duration"))
+
+	var videos []types.Video
+	for _, video := range s.Fixtures.Videos {
+		if video.Width < minWidth || video.Height < minHeight || video.Duration < minDuration {
+			continue
+		}
+		if maxDuration > 0 && video.Duration > maxDuration {
+			continue
+		}
+		videos = append(videos, video)
+	}
+	s.writeVideos(w, r, videos)
+}
+
+func (s *Server) handleVideo(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	for _, video := range s.Fixtures.Videos {
+		if video.ID == id {
+			writeJSON(w, video)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) handleCollections(w http.ResponseWriter, r *http.Request) {
+	s.writeCollections(w, r, s.Fixtures.Collections)
+}
+
+func (s *Server) handleFeatured(w http.ResponseWriter, r *http.Request) {
+	var featured []types.Collection
+	for _, collection := range s.Fixtures.Collections {
+		if slices.Contains(s.Fixtures.Featured, collection.ID) {
+			featured = append(featured, collection)
+		}
+	}
+	s.writeCollections(w, r, featured)
+}
+
+func (s *Server) handleCollectionMedia(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	media, ok := s.Fixtures.Media[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	query := r.URL.Query()
+	var filtered types.MediaList
+	for _, item := range media {
+		switch types.CollectionMediaType(query.Get("type")) {
+		case types.CollectionMediaPhotos:
+			if item.MediaType() != types.MediaTypePhoto {
+				continue
+			}
+		case types.CollectionMediaVideos:
+			if item.MediaType() != types.MediaTypeVideo {
+				continue
+			}
+		}
+		filtered = append(filtered, item)
+	}
+	if types.SortOrder(query.Get("sort")) == types.SortDesc {
+		filtered = slices.Clone(filtered)
+		slices.Reverse(filtered)
+	}
+
+	p, ok := parsePage(w, r, len(filtered))
+	if !ok {
+		return
+	}
+	writeJSON(w, types.MediaResponse{
+		ID:           id,
+		Media:        pageOf(filtered, p),
+		Page:         p.page,
+		PerPage:      p.perPage,
+		TotalResults: len(filtered),
+		PrevPage:     p.prev,
+		NextPage:     p.next,
+	})
+}
+
+// writePhotos writes one page of photos in the format of the photo endpoints.
+func (s *Server) writePhotos(w http.ResponseWriter, r *http.Request, photos []types.Photo) {
+	p, ok := parsePage(w, r, len(photos))
+	if !ok {
+		return
+	}
+	writeJSON(w, types.PhotosResponse{
+		TotalResults: len(photos),
+		Page:         p.page,
+		PerPage:      p.perPage,
+		Photos:       pageOf(photos, p),
+		PrevPage:     p.prev,
+		NextPage:     p.next,
+	})
+}
+
+// writeVideos writes one page of videos in the format of the video endpoints.
+func (s *Server) writeVideos(w http.ResponseWriter, r *http.Request, videos []types.Video) {
+	p, ok := parsePage(w, r, len(videos))
+	if !ok {
+		return
+	}
+	writeJSON(w, types.VideosResponse{
+		Page:         p.page,
+		PerPage:      p.perPage,
+		TotalResults: len(videos),
+		URL:          "https://www.pexels.com/videos/",
+		Videos:       pageOf(videos, p),
+		PrevPage:     p.prev,
+		NextPage:     p.next,
+	})
+}
+
+// writeCollections writes one page of collections in the format of the collection endpoints.
+func (s *Server) writeCollections(w http.ResponseWriter, r *http.Request, collections []types.Collection) {
+	p, ok := parsePage(w, r, len(collections))
+	if !ok {
+		return
+	}
+	writeJSON(w, types.CollectionsResponse{
+		Collections:  pageOf(collections, p),
+		Page:         p.page,
+		PerPage:      p.perPage,
+		TotalResults: len(collections),
+		PrevPage:     p.prev,
+		NextPage:     p.next,
+	})
+}
+
+// page describes the requested window of a result list and its neighbouring page links.
+type page struct {
+	page, perPage int
+	prev, next    string
+}
+
+// parsePage reads page and per_page from the request and computes the absolute
+// links to the neighbouring pages. It writes a 400 response and reports false
+// when the parameters are invalid.
+func parsePage(w http.ResponseWriter, r *http.Request, total int) (page, bool) {
+	query := r.URL.Query()
+	p := page{page: 1, perPage: DefaultPerPage}
+
+	if value := query.Get("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "page must be a positive integer")
+			return page{}, false
+		}
+		p.page = n
+	}
+	if value := query.Get("per_page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "per_page must be a positive integer")
+			return page{}, false
+		}
+		p.perPage = min(n, MaxPerPage)
+	}
+
+	link := func(n int) string {
+		linked := url.Values{}
+		for key, values := range query {
+			linked[key] = values
+		}
+		linked.Set("page", strconv.Itoa(n))
+		linked.Set("per_page", strconv.Itoa(p.perPage))
+		return (&url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawQuery: linked.Encode()}).String()
+	}
+	if p.page > 1 {
+		p.prev = link(p.page - 1)
+	}
+	if p.page*p.perPage < total {
+		p.next = link(p.page + 1)
+	}
+	return p, true
+}
+
+// pageOf returns the items on the given page, or an empty slice past the end.
+func pageOf[T any](items []T, p page) []T {
+	start := (p.page - 1) * p.perPage
+	if start >= len(items) {
+		return []T{}
+	}
+	return items[start:min(start+p.perPage, len(items))]
+}
+
+// writeJSON writes v as a 200 JSON response.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, fmt.Sprintf("encoding response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// writeError writes an error in the format used by the Pexels API.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(types.ErrorResponse{Error: message})
+}
@@ -0,0 +1,149 @@
+// Package query encodes parameter structs into URL query values using `url` struct tags.
+//
+// Fields are encoded under the name given in their tag:
+//
+//	type SearchParams struct {
+//		Query   string           `url:"query"`
+//		PerPage int              `url:"per_page,omitempty"` // Skipped when zero
+//		Token   string           `url:"-"`                  // Never encoded
+//		Paging  PaginationParams `url:",inline"`            // Fields encoded at the top level
+//	}
+//
+// Untagged fields use their Go name. Embedded structs are flattened like inline fields.
+// Strings, booleans, integers, floats, their named variants and slices of them are supported.
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// field describes how one struct field is encoded.
+type field struct {
+	name      string
+	index     []int // Index sequence for reflect.Value.FieldByIndex
+	omitEmpty bool
+}
+
+// fieldCache holds the encoded fields of every struct type seen so far.
+var fieldCache sync.Map // map[reflect.Type][]field
+
+// Encode returns the query values of v, which must be a struct or a pointer to one.
+// A nil pointer encodes to empty values.
+func Encode(v any) (url.Values, error) {
+	values := url.Values{}
+	if err := EncodeTo(values, v); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// EncodeTo adds the query values of v to values.
+func EncodeTo(values url.Values, v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("query: cannot encode %T, expected a struct", v)
+	}
+
+	for _, f := range fieldsOf(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		if err := addValue(values, f.name, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addValue formats a field value and adds it under name.
+func addValue(values url.Values, name string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		values.Add(name, v.String())
+	case reflect.Bool:
+		values.Add(name, strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		values.Add(name, strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		values.Add(name, strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		values.Add(name, strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()))
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := addValue(values, name, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return addValue(values, name, v.Elem())
+		}
+	default:
+		return fmt.Errorf("query: cannot encode field %s of kind %s", name, v.Kind())
+	}
+	return nil
+}
+
+// fieldsOf returns the encoded fields of a struct type, computing them once.
+func fieldsOf(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+
+	fields := collectFields(t, nil)
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// collectFields walks the fields of t, flattening inline and embedded structs.
+func collectFields(t reflect.Type, parent []int) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		embedded := sf.Anonymous && sf.Type.Kind() == reflect.Struct
+		if !sf.IsExported() && !embedded {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(sf.Tag.Get("url"), ",")
+		if name == "-" {
+			continue
+		}
+		index := append(append([]int(nil), parent...), i)
+
+		if embedded && name == "" || sf.Type.Kind() == reflect.Struct && hasOption(opts, "inline") {
+			fields = append(fields, collectFields(sf.Type, index)...)
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{name: name, index: index, omitEmpty: hasOption(opts, "omitempty")})
+	}
+	return fields
+}
+
+// hasOption reports whether the comma-separated tag options contain option.
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var current string
+		current, opts, _ = strings.Cut(opts, ",")
+		if current == option {
+			return true
+		}
+	}
+	return false
+}
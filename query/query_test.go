@@ -0,0 +1,98 @@
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+	"github.com/kumarsgoyal/pexels-go/utils"
+)
+
+// Test encoding of tags, omitempty, skipped and inline fields
+func TestEncode(t *testing.T) {
+	values, err := Encode(&types.PhotoSearchParams{Query: "red panda", Color: "#ff0000", PerPage: 40})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := values.Encode(); got != "color=%23ff0000&per_page=40&query=red+panda" {
+		t.Fatalf("Unexpected query: %s", got)
+	}
+
+	values, err = Encode(types.MediaParams{CollectionID: "abc", MediaType: "photos", Pagination: types.PaginationParams{Page: 2}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := values.Encode(); got != "page=2&type=photos" {
+		t.Fatalf("Unexpected media query: %s", got)
+	}
+
+	type embedded struct {
+		Limit int `url:"limit"`
+	}
+	type params struct {
+		embedded
+		Tags    []string `url:"tag,omitempty"`
+		Enabled bool
+		Ratio   float64 `url:"ratio,omitempty"`
+		secret  string
+	}
+	values, err = Encode(params{embedded: embedded{Limit: 5}, Tags: []string{"a", "b"}, Ratio: 1.5, secret: "x"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := values.Encode(); got != "Enabled=false&limit=5&ratio=1.5&tag=a&tag=b" {
+		t.Fatalf("Unexpected query: %s", got)
+	}
+
+	if values, err := Encode((*types.PaginationParams)(nil)); err != nil || len(values) != 0 {
+		t.Fatalf("Expected empty values for a nil pointer, got %v, %v", values, err)
+	}
+	if _, err := Encode("query=cat"); err == nil {
+		t.Fatal("Expected an error for a non-struct value")
+	}
+}
+
+// benchmarkParams is a representative photo search.
+var benchmarkParams = &types.PhotoSearchParams{
+	Query:       "mountain lake",
+	Orientation: types.OrientationLandscape,
+	Size:        types.SizeLarge,
+	Locale:      types.LocaleEnUS,
+	Page:        3,
+	PerPage:     40,
+}
+
+// BenchmarkEncode measures encoding a search through struct tags.
+func BenchmarkEncode(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		values, err := Encode(benchmarkParams)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = values.Encode()
+	}
+}
+
+// BenchmarkCleanParams measures the previous path: a hand-built map filtered by
+// utils.CleanParams and formatted with fmt.Sprintf.
+func BenchmarkCleanParams(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		params := utils.CleanParams(map[string]interface{}{
+			"query":       benchmarkParams.Query,
+			"orientation": benchmarkParams.Orientation,
+			"size":        benchmarkParams.Size,
+			"color":       benchmarkParams.Color,
+			"locale":      benchmarkParams.Locale,
+			"page":        benchmarkParams.Page,
+			"per_page":    benchmarkParams.PerPage,
+		})
+		values := url.Values{}
+		for key, value := range params {
+			values.Add(key, fmt.Sprintf("%v", value))
+		}
+		_ = values.Encode()
+	}
+}
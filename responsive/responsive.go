@@ -0,0 +1,237 @@
+// Package responsive generates responsive image markup for photos, using the
+// Pexels image CDN to serve each candidate at the width the browser picks.
+//
+//	layout := responsive.Layout{
+//		Breakpoints: []responsive.Breakpoint{{MinWidth: 1024, Size: "33vw"}, {MinWidth: 640, Size: "50vw"}},
+//		MaxWidth:    1200,
+//	}
+//	html := responsive.Img(&photo, layout) // template.HTML, safe to insert into html/template
+//
+// Attribute values are HTML-escaped and URLs other than http and https are dropped.
+package responsive
+
+import (
+	"cmp"
+	"fmt"
+	"html/template"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// DefaultWidths are the candidate widths of a srcset when Layout.Widths is empty.
+var DefaultWidths = []int{320, 480, 640, 768, 1024, 1280, 1600, 1920, 2560, 3200, 3840}
+
+// DefaultDPRs are the device pixel ratios served when Layout.DPRs is empty.
+var DefaultDPRs = []float64{1, 2}
+
+// fallbackWidth is the width of the src fallback when the layout has no MaxWidth.
+const fallbackWidth = 1024
+
+// colorPattern matches the hexadecimal colors accepted as placeholders.
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{3,8}$`)
+
+// Breakpoint gives the rendered width of the image from a viewport width up.
+type Breakpoint struct {
+	MinWidth int    // Minimum viewport width in CSS pixels
+	Size     string // Rendered width, e.g. "50vw", "600px" or "calc(100vw - 2rem)"
+}
+
+// Art is an alternative crop of the photo for the viewports matching Media,
+// emitted as a <source> of a <picture> element.
+type Art struct {
+	Media       string  // Media query, e.g. "(max-width: 639px)"
+	AspectRatio float64 // Width divided by height of the crop, e.g. 1 for a square
+}
+
+// Layout describes how a photo is rendered.
+type Layout struct {
+	Breakpoints []Breakpoint // Rendered widths by viewport width, in any order
+	MaxWidth    int          // Largest rendered width in CSS pixels; 0 means the full viewport
+	DPRs        []float64    // Device pixel ratios to serve; defaults to DefaultDPRs
+	Widths      []int        // Candidate widths; defaults to DefaultWidths
+	Eager       bool         // Load immediately rather than lazily, e.g. for images above the fold
+	Art         []Art        // Alternative crops used by Picture
+}
+
+// SrcSet returns the srcset of the photo: one CDN URL per candidate width, up to the
+// largest width the layout may need and never wider than the photo itself.
+func SrcSet(photo *types.Photo, layout Layout) template.Srcset {
+	return template.Srcset(srcSet(photo, layout, 0))
+}
+
+// Sizes returns the sizes attribute of the layout, widest breakpoint first.
+func Sizes(layout Layout) string {
+	breakpoints := slices.SortedFunc(slices.Values(layout.Breakpoints), func(a, b Breakpoint) int {
+		return cmp.Compare(b.MinWidth, a.MinWidth)
+	})
+
+	var parts []string
+	for _, bp := range breakpoints {
+		if bp.MinWidth > 0 {
+			parts = append(parts, fmt.Sprintf("(min-width: %dpx) %s", bp.MinWidth, bp.Size))
+		} else {
+			parts = append(parts, bp.Size)
+		}
+	}
+	if len(breakpoints) == 0 || breakpoints[len(breakpoints)-1].MinWidth > 0 {
+		parts = append(parts, defaultSize(layout))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Img returns an <img> element for the photo with srcset, sizes, intrinsic
+// dimensions, alt text, an average color placeholder and lazy loading.
+func Img(photo *types.Photo, layout Layout) template.HTML {
+	var b strings.Builder
+	writeImg(&b, photo, layout)
+	return template.HTML(b.String())
+}
+
+// Picture returns a <picture> element holding one <source> per Art crop of the
+// layout, in order, followed by the <img> element returned by Img.
+func Picture(photo *types.Photo, layout Layout) template.HTML {
+	var b strings.Builder
+	b.WriteString("<picture>")
+	sizes := Sizes(layout)
+	for _, art := range layout.Art {
+		if art.AspectRatio <= 0 {
+			continue
+		}
+		b.WriteString("<source")
+		writeAttr(&b, "media", art.Media)
+		writeAttr(&b, "srcset", srcSet(photo, layout, art.AspectRatio))
+		writeAttr(&b, "sizes", sizes)
+		if photo.Width > 0 && photo.Height > 0 {
+			width, height := crop(photo.Width, photo.Height, art.AspectRatio)
+			writeAttr(&b, "width", strconv.Itoa(width))
+			writeAttr(&b, "height", strconv.Itoa(height))
+		}
+		b.WriteString(">")
+	}
+	writeImg(&b, photo, layout)
+	b.WriteString("</picture>")
+	return template.HTML(b.String())
+}
+
+// writeImg writes the <img> element of the photo.
+func writeImg(b *strings.Builder, photo *types.Photo, layout Layout) {
+	b.WriteString("<img")
+	writeAttr(b, "src", safeURL(imageURL(photo, min(cmp.Or(layout.MaxWidth, fallbackWidth), widthLimit(photo)), 0)))
+	writeAttr(b, "srcset", srcSet(photo, layout, 0))
+	writeAttr(b, "sizes", Sizes(layout))
+	if photo.Width > 0 && photo.Height > 0 {
+		writeAttr(b, "width", strconv.Itoa(photo.Width))
+		writeAttr(b, "height", strconv.Itoa(photo.Height))
+	}
+	writeAttr(b, "alt", photo.Alt)
+	if layout.Eager {
+		writeAttr(b, "loading", "eager")
+	} else {
+		writeAttr(b, "loading", "lazy")
+	}
+	writeAttr(b, "decoding", "async")
+	if colorPattern.MatchString(photo.AvgColor) {
+		writeAttr(b, "style", "background-color:"+photo.AvgColor)
+	}
+	b.WriteString(">")
+}
+
+// writeAttr writes an HTML-escaped attribute. Empty values are skipped, except for alt,
+// where an empty value marks the image as decorative.
+func writeAttr(b *strings.Builder, name, value string) {
+	if value == "" && name != "alt" {
+		return
+	}
+	b.WriteString(" ")
+	b.WriteString(name)
+	b.WriteString(`="`)
+	b.WriteString(template.HTMLEscapeString(value))
+	b.WriteString(`"`)
+}
+
+// srcSet returns the srcset of the photo, cropped to aspectRatio when it is positive.
+func srcSet(photo *types.Photo, layout Layout, aspectRatio float64) string {
+	var candidates []string
+	for _, width := range candidateWidths(photo, layout) {
+		if u := safeURL(imageURL(photo, width, aspectRatio)); u != "" {
+			candidates = append(candidates, fmt.Sprintf("%s %dw", u, width))
+		}
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// candidateWidths returns the widths offered in a srcset, in increasing order.
+func candidateWidths(photo *types.Photo, layout Layout) []int {
+	limit := widthLimit(photo)
+	if layout.MaxWidth > 0 {
+		dprs := layout.DPRs
+		if len(dprs) == 0 {
+			dprs = DefaultDPRs
+		}
+		limit = min(limit, int(math.Ceil(float64(layout.MaxWidth)*slices.Max(dprs))))
+	}
+
+	widths := layout.Widths
+	if len(widths) == 0 {
+		widths = DefaultWidths
+	}
+	var candidates []int
+	for _, width := range slices.Sorted(slices.Values(widths)) {
+		if width > 0 && width < limit && !slices.Contains(candidates, width) {
+			candidates = append(candidates, width)
+		}
+	}
+	if limit < math.MaxInt {
+		candidates = append(candidates, limit)
+	}
+	return candidates
+}
+
+// widthLimit returns the width of the photo, or math.MaxInt when it is unknown.
+func widthLimit(photo *types.Photo) int {
+	if photo.Width <= 0 {
+		return math.MaxInt
+	}
+	return photo.Width
+}
+
+// imageURL returns the CDN URL of the photo at width, cropped to aspectRatio when it is positive.
+func imageURL(photo *types.Photo, width int, aspectRatio float64) string {
+	u := photo.ImageURL().Compress().Width(width)
+	if aspectRatio > 0 {
+		u = u.Height(int(math.Round(float64(width) / aspectRatio))).Fit(types.FitCrop)
+	}
+	return u.String()
+}
+
+// crop returns the largest width and height of the given aspect ratio that fit in the photo.
+func crop(width, height int, aspectRatio float64) (int, int) {
+	if float64(width)/float64(height) > aspectRatio {
+		return int(math.Round(float64(height) * aspectRatio)), height
+	}
+	return width, int(math.Round(float64(width) / aspectRatio))
+}
+
+// defaultSize returns the rendered width below the smallest breakpoint.
+func defaultSize(layout Layout) string {
+	if layout.MaxWidth > 0 {
+		return fmt.Sprintf("min(100vw, %dpx)", layout.MaxWidth)
+	}
+	return "100vw"
+}
+
+// safeURL returns rawURL if it is an absolute http or https URL, and "" otherwise.
+func safeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	// Commas and spaces separate srcset candidates
+	return strings.NewReplacer(",", "%2C", " ", "%20").Replace(rawURL)
+}
@@ -0,0 +1,109 @@
+package responsive
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+const original = "https://images.pexels.com/photos/2014422/pexels-photo-2014422.jpeg"
+
+func testPhoto() *types.Photo {
+	return &types.Photo{
+		ID:       2014422,
+		Width:    3000,
+		Height:   2000,
+		Alt:      `Brown "rock" <formation>`,
+		AvgColor: "#978E82",
+		Src:      types.PhotoSrc{Original: original},
+	}
+}
+
+// Test srcset widths and sizes
+func TestSrcSetAndSizes(t *testing.T) {
+	layout := Layout{
+		Breakpoints: []Breakpoint{{MinWidth: 640, Size: "50vw"}, {MinWidth: 1024, Size: "33vw"}},
+		MaxWidth:    800,
+		Widths:      []int{1280, 320, 640, 320},
+	}
+
+	want := original + "?auto=compress&cs=tinysrgb&w=320 320w, " +
+		original + "?auto=compress&cs=tinysrgb&w=640 640w, " +
+		original + "?auto=compress&cs=tinysrgb&w=1280 1280w, " +
+		original + "?auto=compress&cs=tinysrgb&w=1600 1600w"
+	if got := SrcSet(testPhoto(), layout); string(got) != want {
+		t.Errorf("Unexpected srcset:\n got %s\nwant %s", got, want)
+	}
+
+	// Widths are capped by the photo width when the layout has no maximum
+	small := testPhoto()
+	small.Width = 500
+	if got := SrcSet(small, Layout{Widths: []int{320, 640}}); !strings.HasSuffix(string(got), "w=500 500w") || strings.Contains(string(got), "640w") {
+		t.Errorf("Expected candidates capped at the photo width, got %s", got)
+	}
+
+	if got, want := Sizes(layout), "(min-width: 1024px) 33vw, (min-width: 640px) 50vw, min(100vw, 800px)"; got != want {
+		t.Errorf("Sizes() = %q, want %q", got, want)
+	}
+	if got, want := Sizes(Layout{Breakpoints: []Breakpoint{{Size: "90vw"}, {MinWidth: 800, Size: "400px"}}}), "(min-width: 800px) 400px, 90vw"; got != want {
+		t.Errorf("Sizes() = %q, want %q", got, want)
+	}
+}
+
+// Test the img and picture markup, including escaping
+func TestMarkup(t *testing.T) {
+	photo := testPhoto()
+	img := string(Img(photo, Layout{MaxWidth: 600, Widths: []int{600}}))
+
+	for _, want := range []string{
+		`<img src="` + original + `?auto=compress&amp;cs=tinysrgb&amp;w=600"`,
+		`srcset="` + original + `?auto=compress&amp;cs=tinysrgb&amp;w=600 600w, `,
+		`sizes="min(100vw, 600px)"`,
+		`width="3000" height="2000"`,
+		`alt="Brown &#34;rock&#34; &lt;formation&gt;"`,
+		`loading="lazy" decoding="async"`,
+		`style="background-color:#978E82">`,
+	} {
+		if !strings.Contains(img, want) {
+			t.Errorf("Expected %s in %s", want, img)
+		}
+	}
+
+	photo.AvgColor = `red;background:url(javascript:alert(1))`
+	photo.Src.Original = "javascript:alert(1)"
+	img = string(Img(photo, Layout{Eager: true}))
+	if strings.Contains(img, "style=") || strings.Contains(img, "javascript") || !strings.Contains(img, `loading="eager"`) {
+		t.Errorf("Expected unsafe values to be dropped, got %s", img)
+	}
+
+	picture := string(Picture(testPhoto(), Layout{MaxWidth: 400, Widths: []int{400}, Art: []Art{{Media: "(max-width: 639px)", AspectRatio: 1}}}))
+	for _, want := range []string{
+		`<picture><source media="(max-width: 639px)"`,
+		`srcset="` + original + `?auto=compress&amp;cs=tinysrgb&amp;fit=crop&amp;h=400&amp;w=400 400w, `,
+		`width="2000" height="2000">`,
+		`<img src=`,
+		`</picture>`,
+	} {
+		if !strings.Contains(picture, want) {
+			t.Errorf("Expected %s in %s", want, picture)
+		}
+	}
+}
+
+// Test that the output is inserted into html/template without double escaping
+func TestTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("page").Parse(`<div>{{.Img}}</div><img srcset="{{.SrcSet}}">`))
+	var b strings.Builder
+	err := tmpl.Execute(&b, map[string]any{
+		"Img":    Img(testPhoto(), Layout{}),
+		"SrcSet": SrcSet(testPhoto(), Layout{Widths: []int{320}}),
+	})
+	if err != nil {
+		t.Fatalf("Error executing template: %v", err)
+	}
+	if !strings.HasPrefix(b.String(), "<div><img src=") || !strings.Contains(b.String(), `srcset="`+original+`?auto=compress&amp;cs=tinysrgb&amp;w=320 320w, `) {
+		t.Errorf("Unexpected template output: %s", b.String())
+	}
+}
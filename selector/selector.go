@@ -0,0 +1,182 @@
+// Package selector chooses among the files of a video, as listed in
+// types.Video.VideoFiles and types.MediaItem.VideoFiles.
+//
+// A Criteria filters the files and orders the remaining candidates:
+//
+//	// Largest mp4 no wider than 1920 pixels at 30 fps or more
+//	largest := selector.Criteria{FileType: "video/mp4", MaxWidth: 1920, MinFPS: 30}
+//	file, ok := largest.Select(video.VideoFiles)
+//
+//	// Smallest HD file
+//	smallest := selector.Criteria{Quality: selector.QualityHD, Prefer: selector.PreferSmallest}
+//
+//	// Closest to 720p in portrait orientation
+//	portrait := selector.Criteria{Orientation: types.OrientationPortrait, Prefer: selector.PreferClosest, Target: 720}
+//
+// Select has the signature of download.VideoSelector, so a method value such as
+// largest.Select can be passed to the download package.
+package selector
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// Quality is the quality label of a video file.
+type Quality string
+
+const (
+	QualityUHD Quality = "uhd" // 4K and above
+	QualityHD  Quality = "hd"  // 720p to 1440p
+	QualitySD  Quality = "sd"  // Below 720p
+)
+
+// Preference orders the files that match a Criteria.
+type Preference int
+
+const (
+	PreferLargest  Preference = iota // Most pixels first
+	PreferSmallest                   // Fewest pixels first
+	PreferClosest                    // Resolution closest to Criteria.Target first
+)
+
+// fpsTolerance is the relative shortfall accepted by MinFPS, so that NTSC
+// frame rates such as 29.97 and 59.94 satisfy minimums of 30 and 60.
+const fpsTolerance = 0.002
+
+// Criteria describes the wanted video file. Zero-value fields do not filter.
+//
+// The candidates are ordered by Prefer, then by the following tie-breakers, in order:
+//
+//  1. higher frame rate;
+//  2. files with a quality label before files without one, which are usually
+//     unencoded originals with a much higher bitrate;
+//  3. the order of the input list.
+type Criteria struct {
+	FileType    string            // MIME type, e.g. "video/mp4"
+	Quality     Quality           // Quality label; see QualityOf for files without one
+	MaxWidth    int               // Maximum width in pixels
+	MaxHeight   int               // Maximum height in pixels
+	MinFPS      float64           // Minimum frame rate; 29.97 satisfies 30
+	Orientation types.Orientation // Shape of the frame
+	Prefer      Preference        // Order of the candidates
+	Target      int               // Resolution in the "720p" sense, i.e. the shorter side, for PreferClosest
+}
+
+// Match reports whether file satisfies every filter of c.
+func (c Criteria) Match(file types.VideoFile) bool {
+	switch {
+	case file.Link == "":
+		return false
+	case c.FileType != "" && file.FileType != c.FileType:
+		return false
+	case c.Quality != "" && QualityOf(file) != c.Quality:
+		return false
+	case c.MaxWidth > 0 && file.Width > c.MaxWidth:
+		return false
+	case c.MaxHeight > 0 && file.Height > c.MaxHeight:
+		return false
+	case c.MinFPS > 0 && file.FPS < c.MinFPS*(1-fpsTolerance):
+		return false
+	case c.Orientation != "" && OrientationOf(file) != c.Orientation:
+		return false
+	}
+	return true
+}
+
+// Rank returns the files matching c, best first. The input is left untouched.
+func (c Criteria) Rank(files []types.VideoFile) []types.VideoFile {
+	var candidates []types.VideoFile
+	for _, file := range files {
+		if c.Match(file) {
+			candidates = append(candidates, file)
+		}
+	}
+	slices.SortStableFunc(candidates, c.compare)
+	return candidates
+}
+
+// Select returns the best file matching c, or false when no file matches.
+func (c Criteria) Select(files []types.VideoFile) (types.VideoFile, bool) {
+	ranked := c.Rank(files)
+	if len(ranked) == 0 {
+		return types.VideoFile{}, false
+	}
+	return ranked[0], true
+}
+
+// compare orders a before b when a is the better candidate.
+func (c Criteria) compare(a, b types.VideoFile) int {
+	var order int
+	switch c.Prefer {
+	case PreferSmallest:
+		order = cmp.Compare(pixels(a), pixels(b))
+	case PreferClosest:
+		order = cmp.Compare(distance(a, c.Target), distance(b, c.Target))
+		if order == 0 {
+			// Equally close: the larger file loses less detail
+			order = cmp.Compare(pixels(b), pixels(a))
+		}
+	default:
+		order = cmp.Compare(pixels(b), pixels(a))
+	}
+	if order != 0 {
+		return order
+	}
+	if order = cmp.Compare(b.FPS, a.FPS); order != 0 {
+		return order
+	}
+	return cmp.Compare(unlabeled(a), unlabeled(b))
+}
+
+// QualityOf returns the quality label of file. Files listed without one, such as
+// full resolution originals, are classified by the shorter side of their frame.
+func QualityOf(file types.VideoFile) Quality {
+	if file.Quality != "" {
+		return Quality(file.Quality)
+	}
+	switch short := min(file.Width, file.Height); {
+	case short >= 2160:
+		return QualityUHD
+	case short >= 720:
+		return QualityHD
+	default:
+		return QualitySD
+	}
+}
+
+// OrientationOf returns the orientation of the frame of file.
+func OrientationOf(file types.VideoFile) types.Orientation {
+	switch {
+	case file.Width > file.Height:
+		return types.OrientationLandscape
+	case file.Height > file.Width:
+		return types.OrientationPortrait
+	default:
+		return types.OrientationSquare
+	}
+}
+
+// pixels returns the frame area of file.
+func pixels(file types.VideoFile) int {
+	return file.Width * file.Height
+}
+
+// distance returns how far the shorter side of file is from target.
+func distance(file types.VideoFile, target int) int {
+	d := min(file.Width, file.Height) - target
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// unlabeled returns 1 for files without a quality label and 0 otherwise.
+func unlabeled(file types.VideoFile) int {
+	if file.Quality == "" {
+		return 1
+	}
+	return 0
+}
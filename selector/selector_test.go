@@ -0,0 +1,87 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/kumarsgoyal/pexels-go/download"
+	"github.com/kumarsgoyal/pexels-go/types"
+)
+
+// files mirrors the video_files of a typical landscape video, including an unlabeled original.
+var files = []types.VideoFile{
+	{ID: 1, Quality: "sd", FileType: "video/mp4", Width: 640, Height: 360, FPS: 29.97, Link: "sd360"},
+	{ID: 2, Quality: "hd", FileType: "video/mp4", Width: 1920, Height: 1080, FPS: 29.97, Link: "hd1080"},
+	{ID: 3, Quality: "hd", FileType: "video/mp4", Width: 1280, Height: 720, FPS: 25, Link: "hd720-25"},
+	{ID: 4, Quality: "hd", FileType: "video/mp4", Width: 1280, Height: 720, FPS: 50, Link: "hd720-50"},
+	{ID: 5, Quality: "uhd", FileType: "video/mp4", Width: 3840, Height: 2160, FPS: 29.97, Link: "uhd"},
+	{ID: 6, Quality: "", FileType: "video/mp4", Width: 1920, Height: 1080, FPS: 29.97, Link: "original"},
+	{ID: 7, Quality: "hd", FileType: "video/webm", Width: 1920, Height: 1080, FPS: 60, Link: "webm"},
+	{ID: 8, Quality: "sd", FileType: "video/mp4", Width: 540, Height: 960, FPS: 30, Link: "portrait"},
+	{ID: 9, Quality: "hd", FileType: "video/mp4", Width: 1920, Height: 1080, FPS: 30},
+}
+
+// Test selection and ranking, including tie-breaking
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria Criteria
+		want     []string // Links of the ranked candidates
+	}{
+		{"largest mp4 up to 1920 at 30fps", Criteria{FileType: "video/mp4", MaxWidth: 1920, MinFPS: 30}, []string{"hd1080", "original", "hd720-50", "portrait", "sd360"}},
+		{"smallest hd", Criteria{Quality: QualityHD, Prefer: PreferSmallest}, []string{"hd720-50", "hd720-25", "webm", "hd1080", "original"}},
+		{"closest to 720p", Criteria{Prefer: PreferClosest, Target: 720, MaxHeight: 1080}, []string{"hd720-50", "hd720-25", "portrait", "webm", "hd1080", "original", "sd360"}},
+		{"portrait", Criteria{Orientation: types.OrientationPortrait, Prefer: PreferClosest, Target: 720}, []string{"portrait"}},
+		{"unlabeled uhd", Criteria{Quality: QualityUHD}, []string{"uhd"}},
+		{"no match", Criteria{FileType: "video/quicktime"}, nil},
+	}
+
+	for _, test := range tests {
+		var got []string
+		for _, file := range test.criteria.Rank(files) {
+			got = append(got, file.Link)
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+				break
+			}
+		}
+
+		file, ok := test.criteria.Select(files)
+		if ok != (len(test.want) > 0) || ok && file.Link != test.want[0] {
+			t.Errorf("%s: Select returned %q, %v", test.name, file.Link, ok)
+		}
+	}
+}
+
+// Test that files without a quality label are classified by resolution
+func TestQualityOf(t *testing.T) {
+	tests := []struct {
+		file types.VideoFile
+		want Quality
+	}{
+		{types.VideoFile{Quality: "sd", Width: 3840, Height: 2160}, QualitySD},
+		{types.VideoFile{Width: 4096, Height: 2160}, QualityUHD},
+		{types.VideoFile{Width: 720, Height: 1280}, QualityHD},
+		{types.VideoFile{Width: 640, Height: 360}, QualitySD},
+	}
+	for _, test := range tests {
+		if got := QualityOf(test.file); got != test.want {
+			t.Errorf("QualityOf(%+v) = %q, want %q", test.file, got, test.want)
+		}
+	}
+}
+
+// Test that a Criteria plugs into the download package and into collection media
+func TestDownloadSelector(t *testing.T) {
+	var selectVideo download.VideoSelector = Criteria{Quality: QualitySD, Prefer: PreferSmallest}.Select
+
+	item := types.MediaItem{Type: types.MediaTypeVideo, VideoFiles: files}
+	if file, ok := selectVideo(item.VideoFiles); !ok || file.Link != "sd360" {
+		t.Fatalf("Unexpected selection %+v", file)
+	}
+}
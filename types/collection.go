@@ -23,16 +23,18 @@ type Collection struct {
 
 // MediaResponse defines the structure of the response when fetching media from a collection.
 type MediaResponse struct {
-	ID           string      `json:"id"`                  // Collection ID
-	Media        []MediaItem `json:"media"`               // Array of media items (photos or videos)
-	Page         int         `json:"page"`                // Current page number
-	PerPage      int         `json:"per_page"`            // Results per page
-	TotalResults int         `json:"total_results"`       // Total number of media items in the collection
-	PrevPage     string      `json:"prev_page,omitempty"` // Optional: URL for the previous page
-	NextPage     string      `json:"next_page,omitempty"` // Optional: URL for the next page
+	ID           string    `json:"id"`                  // Collection ID
+	Media        MediaList `json:"media"`               // Photos and videos, decoded as *Photo and *Video
+	Page         int       `json:"page"`                // Current page number
+	PerPage      int       `json:"per_page"`            // Results per page
+	TotalResults int       `json:"total_results"`       // Total number of media items in the collection
+	PrevPage     string    `json:"prev_page,omitempty"` // Optional: URL for the previous page
+	NextPage     string    `json:"next_page,omitempty"` // Optional: URL for the next page
 }
 
-// MediaItem can be a photo or a video, representing media in the response.
+// MediaItem is a flattened representation of a photo or a video, as found in
+// collection media. MediaResponse decodes media into *Photo and *Video instead;
+// use NewMediaItem and MediaItem.Media to convert between the two.
 type MediaItem struct {
 	Type            string         `json:"type"`                       // "Photo" or "Video"
 	ID              int            `json:"id"`                         // Media ID
@@ -0,0 +1,160 @@
+package types
+
+import (
+	"regexp"
+	"slices"
+)
+
+// MaxPerPage is the largest number of results per page accepted by the API.
+const MaxPerPage = 80
+
+// Orientation filters search results by the shape of the media.
+type Orientation string
+
+const (
+	OrientationLandscape Orientation = "landscape" // Wider than tall
+	OrientationPortrait  Orientation = "portrait"  // Taller than wide
+	OrientationSquare    Orientation = "square"    // Roughly as wide as tall
+)
+
+// Orientations lists the supported orientations.
+var Orientations = []Orientation{OrientationLandscape, OrientationPortrait, OrientationSquare}
+
+// Valid reports whether o is unset or a supported orientation.
+func (o Orientation) Valid() bool {
+	return o == "" || slices.Contains(Orientations, o)
+}
+
+// Size filters search results by minimum resolution.
+type Size string
+
+const (
+	SizeLarge  Size = "large"  // Photos of 24MP and videos in 4K
+	SizeMedium Size = "medium" // Photos of 12MP and videos in Full HD
+	SizeSmall  Size = "small"  // Photos of 4MP and videos in HD
+)
+
+// Sizes lists the supported sizes.
+var Sizes = []Size{SizeLarge, SizeMedium, SizeSmall}
+
+// Valid reports whether s is unset or a supported size.
+func (s Size) Valid() bool {
+	return s == "" || slices.Contains(Sizes, s)
+}
+
+// CollectionMediaType filters the media of a collection by type.
+type CollectionMediaType string
+
+const (
+	CollectionMediaPhotos CollectionMediaType = "photos" // Only the photos of the collection
+	CollectionMediaVideos CollectionMediaType = "videos" // Only the videos of the collection
+)
+
+// CollectionMediaTypes lists the supported collection media types.
+var CollectionMediaTypes = []CollectionMediaType{CollectionMediaPhotos, CollectionMediaVideos}
+
+// Valid reports whether t is unset or a supported collection media type.
+func (t CollectionMediaType) Valid() bool {
+	return t == "" || slices.Contains(CollectionMediaTypes, t)
+}
+
+// SortOrder orders the media of a collection by the time they were added.
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"  // Oldest first
+	SortDesc SortOrder = "desc" // Newest first
+)
+
+// SortOrders lists the supported sort orders.
+var SortOrders = []SortOrder{SortAsc, SortDesc}
+
+// Valid reports whether o is unset or a supported sort order.
+func (o SortOrder) Valid() bool {
+	return o == "" || slices.Contains(SortOrders, o)
+}
+
+// Color filters photo search results by dominant color. It is either one of
+// the named colors or a hexadecimal code such as "#ffffff".
+type Color string
+
+const (
+	ColorRed       Color = "red"
+	ColorOrange    Color = "orange"
+	ColorYellow    Color = "yellow"
+	ColorGreen     Color = "green"
+	ColorTurquoise Color = "turquoise"
+	ColorBlue      Color = "blue"
+	ColorViolet    Color = "violet"
+	ColorPink      Color = "pink"
+	ColorBrown     Color = "brown"
+	ColorBlack     Color = "black"
+	ColorGray      Color = "gray"
+	ColorWhite     Color = "white"
+)
+
+// Colors lists the named colors supported by the API.
+var Colors = []Color{
+	ColorRed, ColorOrange, ColorYellow, ColorGreen, ColorTurquoise, ColorBlue,
+	ColorViolet, ColorPink, ColorBrown, ColorBlack, ColorGray, ColorWhite,
+}
+
+// hexColorPattern matches six-digit hexadecimal color codes.
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// IsHex reports whether c is a hexadecimal color code rather than a named color.
+func (c Color) IsHex() bool {
+	return hexColorPattern.MatchString(string(c))
+}
+
+// Valid reports whether c is unset, a named color or a well-formed hexadecimal code.
+func (c Color) Valid() bool {
+	return c == "" || slices.Contains(Colors, c) || c.IsHex()
+}
+
+// Locale selects the language of the search query.
+type Locale string
+
+const (
+	LocaleEnUS Locale = "en-US"
+	LocalePtBR Locale = "pt-BR"
+	LocaleEsES Locale = "es-ES"
+	LocaleCaES Locale = "ca-ES"
+	LocaleDeDE Locale = "de-DE"
+	LocaleItIT Locale = "it-IT"
+	LocaleFrFR Locale = "fr-FR"
+	LocaleSvSE Locale = "sv-SE"
+	LocaleIdID Locale = "id-ID"
+	LocalePlPL Locale = "pl-PL"
+	LocaleJaJP Locale = "ja-JP"
+	LocaleZhTW Locale = "zh-TW"
+	LocaleZhCN Locale = "zh-CN"
+	LocaleKoKR Locale = "ko-KR"
+	LocaleThTH Locale = "th-TH"
+	LocaleNlNL Locale = "nl-NL"
+	LocaleHuHU Locale = "hu-HU"
+	LocaleViVN Locale = "vi-VN"
+	LocaleCsCZ Locale = "cs-CZ"
+	LocaleDaDK Locale = "da-DK"
+	LocaleFiFI Locale = "fi-FI"
+	LocaleUkUA Locale = "uk-UA"
+	LocaleElGR Locale = "el-GR"
+	LocaleRoRO Locale = "ro-RO"
+	LocaleNbNO Locale = "nb-NO"
+	LocaleSkSK Locale = "sk-SK"
+	LocaleTrTR Locale = "tr-TR"
+	LocaleRuRU Locale = "ru-RU"
+)
+
+// Locales lists the locales supported by the API.
+var Locales = []Locale{
+	LocaleEnUS, LocalePtBR, LocaleEsES, LocaleCaES, LocaleDeDE, LocaleItIT, LocaleFrFR,
+	LocaleSvSE, LocaleIdID, LocalePlPL, LocaleJaJP, LocaleZhTW, LocaleZhCN, LocaleKoKR,
+	LocaleThTH, LocaleNlNL, LocaleHuHU, LocaleViVN, LocaleCsCZ, LocaleDaDK, LocaleFiFI,
+	LocaleUkUA, LocaleElGR, LocaleRoRO, LocaleNbNO, LocaleSkSK, LocaleTrTR, LocaleRuRU,
+}
+
+// Valid reports whether l is unset or a supported locale.
+func (l Locale) Valid() bool {
+	return l == "" || slices.Contains(Locales, l)
+}
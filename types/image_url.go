@@ -0,0 +1,169 @@
+package types
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Query parameters understood by the Pexels image CDN.
+const (
+	ImageParamWidth      = "w"
+	ImageParamHeight     = "h"
+	ImageParamDPR        = "dpr"
+	ImageParamFit        = "fit"
+	ImageParamAuto       = "auto"
+	ImageParamColorSpace = "cs"
+)
+
+// Fit controls how the image CDN fits an image into the requested width and height.
+type Fit string
+
+const (
+	FitCrop Fit = "crop" // Fill the box exactly, cropping what overflows
+	FitClip Fit = "clip" // Fit inside the box, keeping the aspect ratio
+	FitMax  Fit = "max"  // Like FitClip, but never enlarge the image
+)
+
+// ColorSpaceTinySRGB is the compact sRGB profile used by the Src URLs of the API.
+const ColorSpaceTinySRGB = "tinysrgb"
+
+// ImageURL builds URLs of the Pexels image CDN, which resizes and crops photos
+// according to query parameters. Builders are values: every method returns a
+// modified copy and leaves the receiver untouched, so a base URL can be shared.
+//
+//	src := photo.ImageURL().Compress().Fit(types.FitCrop)
+//	thumb := src.Size(400, 300).String()
+//	retina := src.Size(400, 300).DPR(2).String()
+//
+// Parameters already present on the starting URL, e.g. when starting from
+// Src.Large, are preserved unless overridden.
+type ImageURL struct {
+	base   *url.URL   // URL without its query, or nil when the starting URL did not parse
+	raw    string     // The starting URL, returned unchanged when it did not parse or is empty
+	params url.Values // Query parameters
+}
+
+// ParseImageURL returns a builder starting from rawURL and its query parameters.
+func ParseImageURL(rawURL string) (ImageURL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ImageURL{raw: rawURL}, err
+	}
+	params := u.Query()
+	base := *u
+	base.RawQuery = ""
+	return ImageURL{base: &base, raw: rawURL, params: params}, nil
+}
+
+// NewImageURL is like ParseImageURL but ignores parse errors;
+// the builder of an invalid URL always returns it unchanged.
+func NewImageURL(rawURL string) ImageURL {
+	u, _ := ParseImageURL(rawURL)
+	return u
+}
+
+// ImageURL returns a builder starting from the original photo.
+func (p *Photo) ImageURL() ImageURL {
+	return p.Src.ImageURL()
+}
+
+// ImageURL returns a builder starting from the original photo.
+// Use NewImageURL to start from another variant.
+func (s PhotoSrc) ImageURL() ImageURL {
+	return NewImageURL(s.Original)
+}
+
+// Width sets the width in CSS pixels; 0 removes it.
+func (u ImageURL) Width(width int) ImageURL {
+	return u.setInt(ImageParamWidth, width)
+}
+
+// Height sets the height in CSS pixels; 0 removes it.
+func (u ImageURL) Height(height int) ImageURL {
+	return u.setInt(ImageParamHeight, height)
+}
+
+// Size sets the width and the height; 0 removes either.
+func (u ImageURL) Size(width, height int) ImageURL {
+	return u.Width(width).Height(height)
+}
+
+// DPR sets the device pixel ratio, which multiplies the delivered resolution; 0 removes it.
+func (u ImageURL) DPR(dpr float64) ImageURL {
+	if dpr <= 0 {
+		return u.Del(ImageParamDPR)
+	}
+	return u.Set(ImageParamDPR, strconv.FormatFloat(dpr, 'f', -1, 64))
+}
+
+// Fit sets how the image fits into the requested size; "" removes it.
+func (u ImageURL) Fit(fit Fit) ImageURL {
+	return u.Set(ImageParamFit, string(fit))
+}
+
+// Compress asks the CDN to pick an efficient format and quality, like the Src URLs
+// of the API do with auto=compress&cs=tinysrgb.
+func (u ImageURL) Compress() ImageURL {
+	return u.Set(ImageParamAuto, "compress").Set(ImageParamColorSpace, ColorSpaceTinySRGB)
+}
+
+// Uncompressed removes the compression parameters set by Compress.
+func (u ImageURL) Uncompressed() ImageURL {
+	return u.Del(ImageParamAuto).Del(ImageParamColorSpace)
+}
+
+// Set sets a query parameter, replacing any existing value; "" removes it.
+func (u ImageURL) Set(key, value string) ImageURL {
+	if value == "" {
+		return u.Del(key)
+	}
+	u.params = u.clonedParams()
+	u.params.Set(key, value)
+	return u
+}
+
+// Del removes a query parameter.
+func (u ImageURL) Del(key string) ImageURL {
+	u.params = u.clonedParams()
+	u.params.Del(key)
+	return u
+}
+
+// Reset removes every query parameter, including those of the starting URL.
+func (u ImageURL) Reset() ImageURL {
+	u.params = url.Values{}
+	return u
+}
+
+// Params returns a copy of the query parameters.
+func (u ImageURL) Params() url.Values {
+	return u.clonedParams()
+}
+
+// String returns the URL with its parameters in sorted order,
+// or "" when the builder started from an empty URL.
+func (u ImageURL) String() string {
+	if u.base == nil || u.raw == "" {
+		return u.raw
+	}
+	result := *u.base
+	result.RawQuery = u.params.Encode()
+	return result.String()
+}
+
+// setInt sets a positive integer parameter, removing it for other values.
+func (u ImageURL) setInt(key string, value int) ImageURL {
+	if value <= 0 {
+		return u.Del(key)
+	}
+	return u.Set(key, strconv.Itoa(value))
+}
+
+// clonedParams returns a copy of the parameters that can be modified freely.
+func (u ImageURL) clonedParams() url.Values {
+	params := make(url.Values, len(u.params)+1)
+	for key, values := range u.params {
+		params[key] = append([]string(nil), values...)
+	}
+	return params
+}
@@ -0,0 +1,38 @@
+package types
+
+import "testing"
+
+// Test building CDN URLs from the original and from variants with existing parameters
+func TestImageURL(t *testing.T) {
+	photo := &Photo{Src: PhotoSrc{
+		Original: "https://images.pexels.com/photos/2014422/pexels-photo-2014422.jpeg",
+		Large:    "https://images.pexels.com/photos/2014422/pexels-photo-2014422.jpeg?auto=compress&cs=tinysrgb&h=650&w=940",
+	}}
+
+	base := photo.ImageURL().Compress().Fit(FitCrop)
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"original", photo.ImageURL().String(), photo.Src.Original},
+		{"resized", base.Size(400, 300).String(), photo.Src.Original + "?auto=compress&cs=tinysrgb&fit=crop&h=300&w=400"},
+		{"retina", base.Width(400).DPR(2.5).String(), photo.Src.Original + "?auto=compress&cs=tinysrgb&dpr=2.5&fit=crop&w=400"},
+		{"base untouched", base.String(), photo.Src.Original + "?auto=compress&cs=tinysrgb&fit=crop"},
+		{"preserved", NewImageURL(photo.Src.Large).DPR(2).String(), photo.Src.Original + "?auto=compress&cs=tinysrgb&dpr=2&h=650&w=940"},
+		{"overridden", NewImageURL(photo.Src.Large).Width(1200).Height(0).Uncompressed().String(), photo.Src.Original + "?w=1200"},
+		{"reset", NewImageURL(photo.Src.Large).Reset().Set("q", "80").String(), photo.Src.Original + "?q=80"},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, test.got, test.want)
+		}
+	}
+
+	if _, err := ParseImageURL("://bad"); err == nil {
+		t.Error("Expected an error for an invalid URL")
+	}
+	if got := NewImageURL("://bad").Width(10).String(); got != "://bad" {
+		t.Errorf("Expected an invalid URL to be returned unchanged, got %s", got)
+	}
+}
@@ -0,0 +1,268 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Values of the "type" field of collection media.
+const (
+	MediaTypePhoto = "Photo"
+	MediaTypeVideo = "Video"
+)
+
+// Media is a photo or a video. It is implemented only by *Photo and *Video,
+// so a type switch over those two cases is exhaustive:
+//
+//	switch m := media.(type) {
+//	case *types.Photo:
+//		fmt.Println(m.Src.Large)
+//	case *types.Video:
+//		fmt.Println(m.Duration)
+//	}
+type Media interface {
+	MediaType() string    // MediaTypePhoto or MediaTypeVideo
+	MediaID() int         // Photo or video ID
+	MediaURL() string     // URL of the Pexels page of the media
+	MediaWidth() int      // Width in pixels
+	MediaHeight() int     // Height in pixels
+	Creator() User        // Photographer or videographer
+	AspectRatio() float64 // Width divided by height, or 0 when the height is unknown
+
+	isMedia() // Seals the interface
+}
+
+// Compile-time checks that photos and videos are media.
+var (
+	_ Media = (*Photo)(nil)
+	_ Media = (*Video)(nil)
+)
+
+// MediaType returns MediaTypePhoto.
+func (p *Photo) MediaType() string { return MediaTypePhoto }
+
+// MediaID returns the photo ID.
+func (p *Photo) MediaID() int { return p.ID }
+
+// MediaURL returns the URL of the photo page.
+func (p *Photo) MediaURL() string { return p.URL }
+
+// MediaWidth returns the photo width.
+func (p *Photo) MediaWidth() int { return p.Width }
+
+// MediaHeight returns the photo height.
+func (p *Photo) MediaHeight() int { return p.Height }
+
+// Creator returns the photographer.
+func (p *Photo) Creator() User {
+	return User{ID: p.PhotographerID, Name: p.Photographer, URL: p.PhotographerURL}
+}
+
+// AspectRatio returns the width of the photo divided by its height.
+func (p *Photo) AspectRatio() float64 { return aspectRatio(p.Width, p.Height) }
+
+func (p *Photo) isMedia() {}
+
+// MediaType returns MediaTypeVideo.
+func (v *Video) MediaType() string { return MediaTypeVideo }
+
+// MediaID returns the video ID.
+func (v *Video) MediaID() int { return v.ID }
+
+// MediaURL returns the URL of the video page.
+func (v *Video) MediaURL() string { return v.URL }
+
+// MediaWidth returns the video width.
+func (v *Video) MediaWidth() int { return v.Width }
+
+// MediaHeight returns the video height.
+func (v *Video) MediaHeight() int { return v.Height }
+
+// Creator returns the videographer.
+func (v *Video) Creator() User { return v.User }
+
+// AspectRatio returns the width of the video divided by its height.
+func (v *Video) AspectRatio() float64 { return aspectRatio(v.Width, v.Height) }
+
+func (v *Video) isMedia() {}
+
+// aspectRatio divides width by height, returning 0 for an unknown height.
+func aspectRatio(width, height int) float64 {
+	if height == 0 {
+		return 0
+	}
+	return float64(width) / float64(height)
+}
+
+// MediaList is a list of photos and videos. It is encoded as a JSON array of
+// objects discriminated by their "type" field, as returned by the collection media endpoint.
+type MediaList []Media
+
+// UnmarshalJSON decodes each element into a *Photo or a *Video according to its type.
+// Elements of other types, which the API may add in the future, and null elements
+// are skipped so that the rest of the page remains usable.
+func (l *MediaList) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*l = nil
+		return nil
+	}
+
+	list := make(MediaList, 0, len(raw))
+	for i, element := range raw {
+		var discriminator struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(element, &discriminator); err != nil {
+			return fmt.Errorf("media %d: %w", i, err)
+		}
+
+		var media Media
+		switch discriminator.Type {
+		case MediaTypePhoto:
+			media = &Photo{}
+		case MediaTypeVideo:
+			media = &Video{}
+		default:
+			continue
+		}
+		if err := json.Unmarshal(element, media); err != nil {
+			return fmt.Errorf("media %d: %w", i, err)
+		}
+		list = append(list, media)
+	}
+	*l = list
+	return nil
+}
+
+// MarshalJSON encodes each element with its "type" field so that the result decodes back into the same list.
+// Nil elements, including nil *Photo and *Video values, are encoded as null.
+func (l MediaList) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("null"), nil
+	}
+
+	// Embedding the media after the discriminator encodes {"type":"Photo",...}
+	elements := make([]any, len(l))
+	for i, media := range l {
+		switch m := media.(type) {
+		case *Photo:
+			if m != nil {
+				elements[i] = struct {
+					Type string `json:"type"`
+					*Photo
+				}{MediaTypePhoto, m}
+			}
+		case *Video:
+			if m != nil {
+				elements[i] = struct {
+					Type string `json:"type"`
+					*Video
+				}{MediaTypeVideo, m}
+			}
+		}
+	}
+	return json.Marshal(elements)
+}
+
+// Photos returns the photos of the list, in order.
+func (l MediaList) Photos() []*Photo {
+	return mediaOfType[*Photo](l)
+}
+
+// Videos returns the videos of the list, in order.
+func (l MediaList) Videos() []*Video {
+	return mediaOfType[*Video](l)
+}
+
+// mediaOfType returns the elements of the list that have type T.
+func mediaOfType[T Media](l MediaList) []T {
+	var items []T
+	for _, media := range l {
+		if item, ok := media.(T); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Photos returns the photos of the page, in order.
+func (r *MediaResponse) Photos() []*Photo { return r.Media.Photos() }
+
+// Videos returns the videos of the page, in order.
+func (r *MediaResponse) Videos() []*Video { return r.Media.Videos() }
+
+// NewMediaItem converts a photo or video into the flattened MediaItem representation.
+func NewMediaItem(media Media) MediaItem {
+	switch m := media.(type) {
+	case *Photo:
+		src := m.Src
+		return MediaItem{
+			Type:            MediaTypePhoto,
+			ID:              m.ID,
+			Width:           m.Width,
+			Height:          m.Height,
+			URL:             m.URL,
+			Photographer:    m.Photographer,
+			PhotographerURL: m.PhotographerURL,
+			PhotographerID:  m.PhotographerID,
+			AvgColor:        m.AvgColor,
+			Src:             &src,
+			Liked:           m.Liked,
+		}
+	case *Video:
+		user := m.User
+		return MediaItem{
+			Type:          MediaTypeVideo,
+			ID:            m.ID,
+			Width:         m.Width,
+			Height:        m.Height,
+			URL:           m.URL,
+			VideoFiles:    m.VideoFiles,
+			VideoPictures: m.VideoPictures,
+			User:          &user,
+			Duration:      m.Duration,
+		}
+	}
+	return MediaItem{}
+}
+
+// Media converts the item into a *Photo or a *Video according to its Type.
+func (m MediaItem) Media() (Media, error) {
+	switch m.Type {
+	case MediaTypePhoto:
+		photo := &Photo{
+			ID:              m.ID,
+			Width:           m.Width,
+			Height:          m.Height,
+			URL:             m.URL,
+			Photographer:    m.Photographer,
+			PhotographerID:  m.PhotographerID,
+			PhotographerURL: m.PhotographerURL,
+			AvgColor:        m.AvgColor,
+			Liked:           m.Liked,
+		}
+		if m.Src != nil {
+			photo.Src = *m.Src
+		}
+		return photo, nil
+	case MediaTypeVideo:
+		video := &Video{
+			ID:            m.ID,
+			Width:         m.Width,
+			Height:        m.Height,
+			URL:           m.URL,
+			Duration:      m.Duration,
+			VideoFiles:    m.VideoFiles,
+			VideoPictures: m.VideoPictures,
+		}
+		if m.User != nil {
+			video.User = *m.User
+		}
+		return video, nil
+	}
+	return nil, fmt.Errorf("unknown media type %q", m.Type)
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// mediaJSON is collection media in the format returned by the API.
+const mediaJSON = `{"id":"abc","media":[
+	{"type":"Photo","id":1,"width":4000,"height":3000,"url":"https://www.pexels.com/photo/1/","photographer":"Min An","photographer_id":234562,"photographer_url":"https://www.pexels.com/@min-an","src":{"large":"https://images.pexels.com/photos/1/large.jpeg"}},
+	{"type":"Video","id":2,"width":1920,"height":1080,"url":"https://www.pexels.com/video/2/","duration":12,"user":{"id":7,"name":"Anni Roenkae","url":"https://www.pexels.com/@anni"},"video_files":[{"id":3,"quality":"hd","file_type":"video/mp4","width":1920,"height":1080,"fps":25,"link":"https://videos.pexels.com/3.mp4"}]}
+],"page":1,"per_page":15,"total_results":2}`
+
+// Test discriminated decoding, the common accessors and JSON round-tripping of media
+func TestMediaList(t *testing.T) {
+	var response MediaResponse
+	if err := json.Unmarshal([]byte(mediaJSON), &response); err != nil {
+		t.Fatalf("Error decoding media: %v", err)
+	}
+
+	photo, ok := response.Media[0].(*Photo)
+	if !ok || photo.Src.Large == "" || photo.Creator().Name != "Min An" || photo.AspectRatio() != 4.0/3.0 {
+		t.Fatalf("Unexpected photo: %+v", response.Media[0])
+	}
+	video, ok := response.Media[1].(*Video)
+	if !ok || video.Duration != 12 || video.Creator().ID != 7 || video.MediaWidth() != 1920 {
+		t.Fatalf("Unexpected video: %+v", response.Media[1])
+	}
+	if len(response.Photos()) != 1 || len(response.Videos()) != 1 {
+		t.Fatalf("Expected one photo and one video, got %d and %d", len(response.Photos()), len(response.Videos()))
+	}
+
+	encoded, err := json.Marshal(&response)
+	if err != nil {
+		t.Fatalf("Error encoding media: %v", err)
+	}
+	var decoded MediaResponse
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Error decoding encoded media: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, response) {
+		t.Fatalf("Media did not round-trip:\n%s", encoded)
+	}
+
+	// Media types the client does not know yet are skipped
+	if err := json.Unmarshal([]byte(`[{"type":"Gif","id":1},null,{"type":"Photo","id":3},{"type":"Video","id":4}]`), &decoded.Media); err != nil {
+		t.Fatalf("Expected unknown media types to be skipped, got %v", err)
+	}
+	if len(decoded.Media) != 2 || decoded.Media[0].MediaID() != 3 || decoded.Media[1].MediaID() != 4 {
+		t.Fatalf("Unexpected media: %+v", decoded.Media)
+	}
+	if err := json.Unmarshal([]byte(`[{"type":"Photo","id":"x"}]`), &decoded.Media); err == nil {
+		t.Fatal("Expected an error for a malformed photo")
+	}
+}
+
+// Test that nil elements, including typed nil photos and videos, encode as null
+func TestMediaListNil(t *testing.T) {
+	list := MediaList{&Photo{ID: 1}, (*Photo)(nil), nil, (*Video)(nil), &Video{ID: 2}}
+	encoded, err := json.Marshal(list)
+	if err != nil {
+		t.Fatalf("Error encoding media: %v", err)
+	}
+	if !json.Valid(encoded) {
+		t.Fatalf("Invalid JSON: %s", encoded)
+	}
+	var elements []json.RawMessage
+	if err := json.Unmarshal(encoded, &elements); err != nil || len(elements) != 5 {
+		t.Fatalf("Expected 5 elements, got %s", encoded)
+	}
+	for i, want := range []string{`{"type":"Photo","id":1,`, "null", "null", "null", `{"type":"Video","id":2,`} {
+		if got := string(elements[i]); len(got) < len(want) || got[:len(want)] != want {
+			t.Errorf("Element %d: expected %s..., got %s", i, want, got)
+		}
+	}
+}
+
+// Test conversion between Media and the flattened MediaItem
+func TestMediaItemConversion(t *testing.T) {
+	video := &Video{ID: 2, Width: 1080, Height: 1920, Duration: 9, User: User{ID: 7, Name: "Anni"}}
+	item := NewMediaItem(video)
+	if item.Type != MediaTypeVideo || item.User == nil || item.User.Name != "Anni" {
+		t.Fatalf("Unexpected media item: %+v", item)
+	}
+
+	media, err := item.Media()
+	if err != nil {
+		t.Fatalf("Error converting media item: %v", err)
+	}
+	if converted, ok := media.(*Video); !ok || converted.Duration != 9 || converted.AspectRatio() != 0.5625 {
+		t.Fatalf("Unexpected media: %+v", media)
+	}
+}
@@ -2,11 +2,12 @@ package types
 
 // PhotosResponse represents the response for the photo search endpoint.
 type PhotosResponse struct {
-	TotalResults int     `json:"total_results"` // Total number of results
-	Page         int     `json:"page"`          // Current page number
-	PerPage      int     `json:"per_page"`      // Number of results per page
-	Photos       []Photo `json:"photos"`        // Array of photos
-	NextPage     string  `json:"next_page"`     // URL to the next page of results
+	TotalResults int     `json:"total_results"`       // Total number of results
+	Page         int     `json:"page"`                // Current page number
+	PerPage      int     `json:"per_page"`            // Number of results per page
+	Photos       []Photo `json:"photos"`              // Array of photos
+	PrevPage     string  `json:"prev_page,omitempty"` // Optional: URL to the previous page of results
+	NextPage     string  `json:"next_page"`           // URL to the next page of results
 }
 
 // Photo represents an individual photo in the response.
@@ -2,45 +2,45 @@ package types
 
 // PhotoSearchParams represents the search parameters for photo search.
 type PhotoSearchParams struct {
-	Query       string `json:"query"`                 // Required: Search query term
-	Orientation string `json:"orientation,omitempty"` // Optional: Photo orientation ("landscape", "portrait", "square")
-	Size        string `json:"size,omitempty"`        // Optional: Photo size ("large", "medium", "small")
-	Color       string `json:"color,omitempty"`       // Optional: Filter by color
-	Locale      string `json:"locale,omitempty"`      // Optional: Locale for the search ("en-US", "es-ES")
-	Page        int    `json:"page,omitempty"`        // Optional: Page number for pagination
-	PerPage     int    `json:"per_page,omitempty"`    // Optional: Results per page (default 15, max 80)
+	Query       string      `json:"query" url:"query"`                                 // Required: Search query term
+	Orientation Orientation `json:"orientation,omitempty" url:"orientation,omitempty"` // Optional: Photo orientation ("landscape", "portrait", "square")
+	Size        Size        `json:"size,omitempty" url:"size,omitempty"`               // Optional: Photo size ("large", "medium", "small")
+	Color       Color       `json:"color,omitempty" url:"color,omitempty"`             // Optional: Filter by named color or hex code ("red", "#ffffff")
+	Locale      Locale      `json:"locale,omitempty" url:"locale,omitempty"`           // Optional: Locale for the search ("en-US", "es-ES")
+	Page        int         `json:"page,omitempty" url:"page,omitempty"`               // Optional: Page number for pagination
+	PerPage     int         `json:"per_page,omitempty" url:"per_page,omitempty"`       // Optional: Results per page (default 15, max 80)
 }
 
 // VideoSearchParams represents the search parameters for video search.
 type VideoSearchParams struct {
-	Query       string `json:"query"`       // Required: Search query term
-	Orientation string `json:"orientation"` // Optional: Video orientation ("landscape", "portrait")
-	Size        string `json:"size"`        // Optional: Video size
-	Locale      string `json:"locale"`      // Optional: Locale for search
-	Page        int    `json:"page"`        // Optional: Page number for pagination
-	PerPage     int    `json:"per_page"`    // Optional: Results per page
+	Query       string      `json:"query" url:"query"`                       // Required: Search query term
+	Orientation Orientation `json:"orientation" url:"orientation,omitempty"` // Optional: Video orientation ("landscape", "portrait", "square")
+	Size        Size        `json:"size" url:"size,omitempty"`               // Optional: Minimum video size ("large", "medium", "small")
+	Locale      Locale      `json:"locale" url:"locale,omitempty"`           // Optional: Locale for search
+	Page        int         `json:"page" url:"page,omitempty"`               // Optional: Page number for pagination
+	PerPage     int         `json:"per_page" url:"per_page,omitempty"`       // Optional: Results per page (default 15, max 80)
 }
 
 // VideoFilterParams represents additional filters for video search.
 type VideoFilterParams struct {
-	MinWidth    int `json:"min_width,omitempty"`    // Minimum video width in pixels
-	MinHeight   int `json:"min_height,omitempty"`   // Minimum video height in pixels
-	MinDuration int `json:"min_duration,omitempty"` // Minimum video duration in seconds
-	MaxDuration int `json:"max_duration,omitempty"` // Maximum video duration in seconds
-	Page        int `json:"page,omitempty"`         // Page number for pagination
-	PerPage     int `json:"per_page,omitempty"`     // Results per page
+	MinWidth    int `json:"min_width,omitempty" url:"min_width,omitempty"`       // Minimum video width in pixels
+	MinHeight   int `json:"min_height,omitempty" url:"min_height,omitempty"`     // Minimum video height in pixels
+	MinDuration int `json:"min_duration,omitempty" url:"min_duration,omitempty"` // Minimum video duration in seconds
+	MaxDuration int `json:"max_duration,omitempty" url:"max_duration,omitempty"` // Maximum video duration in seconds
+	Page        int `json:"page,omitempty" url:"page,omitempty"`                 // Page number for pagination
+	PerPage     int `json:"per_page,omitempty" url:"per_page,omitempty"`         // Results per page
 }
 
 // PaginationParams represents the pagination parameters for fetching paginated results.
 type PaginationParams struct {
-	PerPage int `json:"per_page,omitempty"` // Number of results per page
-	Page    int `json:"page,omitempty"`     // Page number for pagination
+	PerPage int `json:"per_page,omitempty" url:"per_page,omitempty"` // Number of results per page
+	Page    int `json:"page,omitempty" url:"page,omitempty"`         // Page number for pagination
 }
 
 // MediaParams encapsulates parameters for fetching collection media
 type MediaParams struct {
-	CollectionID string           // ID of the collection
-	MediaType    string           // Specify media type (photos or videos)
-	Sort         string           // Specify sort order (asc or desc)
-	Pagination   PaginationParams // Pagination parameters (page and per_page)
+	CollectionID string              `url:"-"`              // ID of the collection, part of the path
+	MediaType    CollectionMediaType `url:"type,omitempty"` // Specify media type (photos or videos)
+	Sort         SortOrder           `url:"sort,omitempty"` // Specify sort order (asc or desc)
+	Pagination   PaginationParams    `url:",inline"`        // Pagination parameters (page and per_page)
 }
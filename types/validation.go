@@ -0,0 +1,106 @@
+package types
+
+import "fmt"
+
+// ValidationError reports a parameter rejected before any request is sent.
+type ValidationError struct {
+	Field  string // Query parameter name, e.g. "per_page"
+	Value  any    // Offending value
+	Reason string // Why the value was rejected
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("pexels: invalid %s %v: %s", e.Field, e.Value, e.Reason)
+}
+
+// Validate checks the search parameters and returns a *ValidationError for the first invalid field.
+func (p *PhotoSearchParams) Validate() error {
+	if p.Query == "" {
+		return &ValidationError{Field: "query", Value: `""`, Reason: "a search query is required"}
+	}
+	if err := validateFilters(p.Orientation, p.Size, p.Locale); err != nil {
+		return err
+	}
+	if !p.Color.Valid() {
+		return &ValidationError{Field: "color", Value: p.Color, Reason: "must be a named color or a hex code such as #ffffff"}
+	}
+	return validatePagination(p.Page, p.PerPage)
+}
+
+// Validate checks the search parameters and returns a *ValidationError for the first invalid field.
+func (p *VideoSearchParams) Validate() error {
+	if p.Query == "" {
+		return &ValidationError{Field: "query", Value: `""`, Reason: "a search query is required"}
+	}
+	if err := validateFilters(p.Orientation, p.Size, p.Locale); err != nil {
+		return err
+	}
+	return validatePagination(p.Page, p.PerPage)
+}
+
+// Validate checks the filters and returns a *ValidationError for the first invalid field.
+func (p *VideoFilterParams) Validate() error {
+	for _, field := range []struct {
+		name  string
+		value int
+	}{
+		{"min_width", p.MinWidth},
+		{"min_height", p.MinHeight},
+		{"min_duration", p.MinDuration},
+		{"max_duration", p.MaxDuration},
+	} {
+		if field.value < 0 {
+			return &ValidationError{Field: field.name, Value: field.value, Reason: "must not be negative"}
+		}
+	}
+	if p.MaxDuration > 0 && p.MaxDuration < p.MinDuration {
+		return &ValidationError{Field: "max_duration", Value: p.MaxDuration, Reason: "must not be less than min_duration"}
+	}
+	return validatePagination(p.Page, p.PerPage)
+}
+
+// Validate checks the pagination and returns a *ValidationError for the first invalid field.
+func (p *PaginationParams) Validate() error {
+	return validatePagination(p.Page, p.PerPage)
+}
+
+// Validate checks the media parameters and returns a *ValidationError for the first invalid field.
+func (p *MediaParams) Validate() error {
+	if p.CollectionID == "" {
+		return &ValidationError{Field: "id", Value: `""`, Reason: "a collection ID is required"}
+	}
+	if !p.MediaType.Valid() {
+		return &ValidationError{Field: "type", Value: p.MediaType, Reason: `must be "photos" or "videos"`}
+	}
+	if !p.Sort.Valid() {
+		return &ValidationError{Field: "sort", Value: p.Sort, Reason: `must be "asc" or "desc"`}
+	}
+	return p.Pagination.Validate()
+}
+
+// validateFilters checks the filters shared by photo and video searches.
+func validateFilters(orientation Orientation, size Size, locale Locale) error {
+	if !orientation.Valid() {
+		return &ValidationError{Field: "orientation", Value: orientation, Reason: "must be landscape, portrait or square"}
+	}
+	if !size.Valid() {
+		return &ValidationError{Field: "size", Value: size, Reason: "must be large, medium or small"}
+	}
+	if !locale.Valid() {
+		return &ValidationError{Field: "locale", Value: locale, Reason: "unsupported locale"}
+	}
+	return nil
+}
+
+// validatePagination checks page and per_page. Zero leaves the API default in place,
+// so page only has to be non-negative.
+func validatePagination(page, perPage int) error {
+	if page < 0 {
+		return &ValidationError{Field: "page", Value: page, Reason: "must not be negative"}
+	}
+	if perPage < 0 || perPage > MaxPerPage {
+		return &ValidationError{Field: "per_page", Value: perPage, Reason: fmt.Sprintf("must be between 1 and %d", MaxPerPage)}
+	}
+	return nil
+}
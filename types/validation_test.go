@@ -0,0 +1,51 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test that invalid parameters are rejected with the offending field
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		params interface{ Validate() error }
+		field  string // Empty when the parameters are valid
+	}{
+		{"valid photo search", &PhotoSearchParams{Query: "cat", Orientation: OrientationSquare, Size: SizeLarge, Color: ColorTurquoise, Locale: LocaleDeDE, Page: 2, PerPage: MaxPerPage}, ""},
+		{"hex color", &PhotoSearchParams{Query: "cat", Color: "#00FFaa"}, ""},
+		{"missing query", &PhotoSearchParams{}, "query"},
+		{"bad orientation", &PhotoSearchParams{Query: "cat", Orientation: "diagonal"}, "orientation"},
+		{"bad size", &PhotoSearchParams{Query: "cat", Size: "huge"}, "size"},
+		{"malformed hex", &PhotoSearchParams{Query: "cat", Color: "#fff"}, "color"},
+		{"unknown color", &PhotoSearchParams{Query: "cat", Color: "beige"}, "color"},
+		{"unknown locale", &PhotoSearchParams{Query: "cat", Locale: "xx-XX"}, "locale"},
+		{"per_page too large", &PhotoSearchParams{Query: "cat", PerPage: 81}, "per_page"},
+		{"negative page", &VideoSearchParams{Query: "cat", Page: -1}, "page"},
+		{"video locale", &VideoSearchParams{Query: "cat", Locale: "en"}, "locale"},
+		{"negative width", &VideoFilterParams{MinWidth: -1}, "min_width"},
+		{"inverted durations", &VideoFilterParams{MinDuration: 30, MaxDuration: 10}, "max_duration"},
+		{"valid pagination", &PaginationParams{}, ""},
+		{"default page", &PaginationParams{Page: 0, PerPage: 10}, ""},
+		{"missing collection", &MediaParams{}, "id"},
+		{"valid media", &MediaParams{CollectionID: "abc", MediaType: CollectionMediaVideos, Sort: SortDesc}, ""},
+		{"bad media type", &MediaParams{CollectionID: "abc", MediaType: "gifs"}, "type"},
+		{"bad sort", &MediaParams{CollectionID: "abc", Sort: "random"}, "sort"},
+		{"media per_page", &MediaParams{CollectionID: "abc", Pagination: PaginationParams{PerPage: 100}}, "per_page"},
+	}
+
+	for _, c := range cases {
+		err := c.params.Validate()
+		if c.field == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", c.name, err)
+			}
+			continue
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != c.field {
+			t.Errorf("%s: expected an error for %s, got %v", c.name, c.field, err)
+		}
+	}
+}
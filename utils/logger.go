@@ -1,30 +1,148 @@
 package utils
 
 import (
+	"context"
+	"io"
 	"log"
+	"log/slog"
 	"os"
+	"strings"
 )
 
-const (
-	LogFilePath = "pexels_client.log"
-)
+// LogFilePath is the file written by ConfigureLogging.
+//
+// Deprecated: pass a logger created with NewLogger to client.WithLogger instead.
+const LogFilePath = "pexels_client.log"
 
-// ConfigureLogging sets up global logging settings
-// logFilePath allows specifying a custom log file location.
-func ConfigureLogging() {
-	log.SetFlags(log.Ldate | log.Ltime | log.Lshortfile)
+// redactedValue replaces the value of any attribute that may carry a secret.
+const redactedValue = "[REDACTED]"
 
-	// Open or create the log file
-	logFile, err := os.OpenFile(LogFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0666)
+// sensitiveKeys lists attribute keys whose values are never written to the log.
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"api_key":       true,
+	"apikey":        true,
+	"pexelapikey":   true,
+}
+
+// NewLogger creates a structured text logger writing to w at the given level.
+// Attributes that may carry the API key are redacted.
+func NewLogger(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	}))
+}
+
+// ConfigureLogging makes the default slog logger, and with it the standard log
+// package, append to LogFilePath through NewLogger. The client does not use the
+// default logger, so this no longer captures its requests. When the file cannot
+// be opened, the default logger is left unchanged.
+//
+// Deprecated: pass a logger created with NewLogger to client.WithLogger instead.
+func ConfigureLogging() {
+	logFile, err := os.OpenFile(LogFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o666)
 	if err != nil {
-		log.Fatalf("Failed to set up log file at %s: %v", LogFilePath, err)
+		log.Printf("Failed to set up log file at %s: %v", LogFilePath, err)
+		return
+	}
+	slog.SetDefault(NewLogger(logFile, slog.LevelInfo))
+	slog.Info("logging initialized", "path", LogFilePath)
+}
+
+// DiscardLogger returns a logger that drops every record. It is the default
+// logger of the client so that the library stays silent unless configured.
+func DiscardLogger() *slog.Logger {
+	return slog.New(discardHandler{})
+}
+
+// NewRedactingHandler wraps h so that sensitive attributes, and any attribute
+// value containing one of the given secrets, are redacted before being handled.
+func NewRedactingHandler(h slog.Handler, secrets ...string) slog.Handler {
+	var nonEmpty []string
+	for _, secret := range secrets {
+		if secret != "" {
+			nonEmpty = append(nonEmpty, secret)
+		}
+	}
+	return &redactingHandler{next: h, secrets: nonEmpty}
+}
+
+// redactAttr is a slog ReplaceAttr function hiding the values of sensitive keys.
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, redactedValue)
+	}
+	return a
+}
+
+// discardHandler is a slog.Handler that is never enabled.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
+
+// redactingHandler scrubs secrets from records before passing them on.
+type redactingHandler struct {
+	next    slog.Handler
+	secrets []string
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	scrubbed := slog.NewRecord(record.Time, record.Level, h.scrub(record.Message), record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		scrubbed.AddAttrs(h.redact(a))
+		return true
+	})
+	return h.next.Handle(ctx, scrubbed)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redact(a)
 	}
-	defer func() {
-		if err := logFile.Close(); err != nil {
-			log.Printf("Failed to close log file: %v", err)
+	return &redactingHandler{next: h.next.WithAttrs(redacted), secrets: h.secrets}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{next: h.next.WithGroup(name), secrets: h.secrets}
+}
+
+// redact hides sensitive keys and secret substrings, descending into groups.
+func (h *redactingHandler) redact(a slog.Attr) slog.Attr {
+	a = redactAttr(nil, a)
+	value := a.Value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]any, len(group))
+		for i, ga := range group {
+			redacted[i] = h.redact(ga)
 		}
-	}()
+		return slog.Group(a.Key, redacted...)
+	case slog.KindString:
+		return slog.String(a.Key, h.scrub(value.String()))
+	case slog.KindAny:
+		// Errors and other values are rendered as text only if they contain a secret
+		if text := value.String(); h.scrub(text) != text {
+			return slog.String(a.Key, h.scrub(text))
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: value}
+}
 
-	log.SetOutput(logFile)
-	log.Printf("Logging initialized. Writing logs to %s", LogFilePath)
+// scrub replaces every occurrence of a secret in s.
+func (h *redactingHandler) scrub(s string) string {
+	for _, secret := range h.secrets {
+		s = strings.ReplaceAll(s, secret, redactedValue)
+	}
+	return s
 }
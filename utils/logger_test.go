@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Test that the API key never reaches the log output
+func TestRedactingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewRedactingHandler(NewLogger(&buf, slog.LevelDebug).Handler(), "secret-key"))
+
+	logger.Debug("request failed secret-key",
+		"Authorization", "secret-key",
+		"url", "https://api.pexels.com/v1/search?key=secret-key",
+		"error", errors.New("bad key secret-key"),
+		slog.Group("request", "api_key", "other"),
+	)
+
+	out := buf.String()
+	if strings.Contains(out, "secret-key") || strings.Contains(out, "other") {
+		t.Fatalf("Secret leaked into log output: %s", out)
+	}
+	if !strings.Contains(out, redactedValue) {
+		t.Fatalf("Expected redacted values in log output: %s", out)
+	}
+}
+
+// Test that the deprecated ConfigureLogging points the default logger at LogFilePath
+func TestConfigureLogging(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defaultLogger := slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(defaultLogger)
+		os.Chdir(wd)
+	})
+
+	ConfigureLogging()
+	slog.Info("configured", "api_key", "secret-key")
+	out, err := os.ReadFile(LogFilePath)
+	if err != nil {
+		t.Fatalf("Error reading the log file: %v", err)
+	}
+	if !strings.Contains(string(out), "msg=configured") || strings.Contains(string(out), "secret-key") {
+		t.Fatalf("Unexpected log file content: %s", out)
+	}
+}
@@ -42,9 +42,6 @@ func isZeroValue(value interface{}) bool {
 
 // Helper function to unmarshal responses
 func UnmarshalResponse(body []byte, target interface{}) error {
-	// Log the raw response body for debugging purposes
-	fmt.Printf("Raw response body: %s\n", string(body))
-
 	if err := json.Unmarshal(body, target); err != nil {
 		return fmt.Errorf("error unmarshaling response: %w", err)
 	}